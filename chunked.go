@@ -0,0 +1,117 @@
+package particle
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// The chunked transfer protocol used by CallFunctionChunked. Every message is a single call of the same function:
+//
+//	S:<chunks>,<size>,<crc32>  starts a transfer of a payload with the given size and CRC-32 (IEEE, 8 hex digits),
+//	                           split into the given number of chunks. The device answers 0.
+//	C:<seq>,<data>             sends chunk seq, counted from 0, with up to 52 characters of unpadded base64. The
+//	                           device answers seq, also when the chunk is repeated, because the answer was lost.
+//	E:                         ends the transfer. The device answers 0 if the size and checksum match.
+//
+// A reference implementation of the device side is examples/devices/firmware/chunked.ino.
+const (
+	// chunkDataSize is the number of base64 characters per chunk. It's a multiple of 4, so every chunk decodes on it's
+	// own, and leaves room for the chunk header within MaxFunctionArgumentLength.
+	chunkDataSize = 52
+	// maxChunks keeps the header of a chunk to at most 8 characters.
+	maxChunks = 100000
+	// defaultChunkRetries is the number of retries per message, if no other number was set.
+	defaultChunkRetries = 3
+)
+
+// ErrChunkRejected is returned when a device answered a message of a chunked transfer with an unexpected value, e.g.
+// because the checksum didn't match.
+var ErrChunkRejected = errors.New("device rejected chunked transfer")
+
+// ChunkOptions configures a chunked function call.
+type ChunkOptions struct {
+	// Retries is the number of times a message is repeated after a transport error or timeout before giving up,
+	// defaults to 3. Messages the device rejected aren't repeated. Use a negative number to disable retries.
+	Retries int
+}
+
+// retries returns the number of retries to use.
+func (opts ChunkOptions) retries() int {
+	if opts.Retries == 0 {
+		return defaultChunkRetries
+	}
+
+	if opts.Retries < 0 {
+		return 0
+	}
+
+	return opts.Retries
+}
+
+// CallFunctionChunked transfers a payload larger than MaxFunctionArgumentLength by splitting it across sequential
+// calls of the function with the given name, which has to implement the chunked transfer protocol. Messages which
+// failed or timed out are retried, the device verifies the reassembled payload by it's size and checksum.
+func (d *Device) CallFunctionChunked(name string, payload []byte, opts ChunkOptions) error {
+	encoded := base64.RawStdEncoding.EncodeToString(payload)
+	var chunks []string
+
+	for len(encoded) > chunkDataSize {
+		chunks = append(chunks, encoded[:chunkDataSize])
+		encoded = encoded[chunkDataSize:]
+	}
+
+	if encoded != "" {
+		chunks = append(chunks, encoded)
+	}
+
+	if len(chunks) > maxChunks {
+		return fmt.Errorf("payload of %v bytes needs more than %v chunks", len(payload), maxChunks)
+	}
+
+	start := fmt.Sprintf("S:%d,%d,%08x", len(chunks), len(payload), crc32.ChecksumIEEE(payload))
+
+	if err := d.callChunk(name, start, 0, opts.retries()); err != nil {
+		return err
+	}
+
+	for seq, data := range chunks {
+		if err := d.callChunk(name, fmt.Sprintf("C:%d,%s", seq, data), seq, opts.retries()); err != nil {
+			return err
+		}
+	}
+
+	return d.callChunk(name, "E:", 0, opts.retries())
+}
+
+// callChunk sends a single message of a chunked transfer and retries it until the device answers with the expected
+// value. Negative answers are definite rejections, e.g. of a chunk out of sequence or a checksum mismatch, which can't
+// be fixed by repeating the message, so they're returned right away.
+func (d *Device) callChunk(name, arg string, expected, retries int) error {
+	var err error
+
+	for attempt := 0; attempt <= retries; attempt++ {
+		var resp FunctionResponse
+		resp, err = d.CallFunction(name, arg)
+
+		if errors.Is(err, ErrUnknownFunction) {
+			return err
+		}
+
+		if err == nil && resp.ReturnValue == expected {
+			return nil
+		}
+
+		if err == nil {
+			err = fmt.Errorf("%v on device %v answered %v to %q, expected %v: %w", name, d.ID, resp.ReturnValue, arg,
+				expected, ErrChunkRejected)
+
+			if resp.ReturnValue < 0 {
+				return err
+			}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,113 @@
+package particle
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// chunkReceiver mimics the reference firmware of the chunked transfer protocol.
+type chunkReceiver struct {
+	chunks, next int
+	size         int
+	checksum     uint32
+	payload      []byte
+	// fail makes the first call of the listed messages time out.
+	fail map[string]bool
+	// flip corrupts the first byte of the payload.
+	flip bool
+	// ends counts the received ends of transfers.
+	ends int
+}
+
+func (c *chunkReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	arg := r.PostFormValue("arg")
+
+	if c.fail[arg] {
+		delete(c.fail, arg)
+		http.Error(w, `{"ok": false, "error": "Timed out."}`, http.StatusRequestTimeout)
+		return
+	}
+
+	ret := -1
+
+	switch {
+	case strings.HasPrefix(arg, "S:"):
+		fmt.Sscanf(arg[2:], "%d,%d,%x", &c.chunks, &c.size, &c.checksum)
+		c.payload, c.next, ret = nil, 0, 0
+	case strings.HasPrefix(arg, "C:"):
+		var seq int
+		var data string
+		fmt.Sscanf(strings.Replace(arg[2:], ",", " ", 1), "%d %s", &seq, &data)
+
+		if seq == c.next-1 {
+			ret = seq
+		} else if seq == c.next {
+			decoded, _ := base64.RawStdEncoding.DecodeString(data)
+			c.payload = append(c.payload, decoded...)
+			c.next++
+			ret = seq
+		} else {
+			ret = -2
+		}
+	case arg == "E:":
+		c.ends++
+
+		if c.flip {
+			c.payload[0] ^= 0xff
+		}
+
+		if c.next == c.chunks && len(c.payload) == c.size && crc32.ChecksumIEEE(c.payload) == c.checksum {
+			ret = 0
+		} else {
+			ret = -3
+		}
+	}
+
+	io.WriteString(w, fmt.Sprintf(`{"id": "1", "connected": true, "return_value": %d}`, ret))
+}
+
+func TestDevice_CallFunctionChunked(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "photon", 10)
+	device.Functions = []string{"config"}
+	receiver := &chunkReceiver{fail: map[string]bool{}}
+	mux.Handle(deviceURL+"/1/config", receiver)
+
+	payload := bytes.Repeat([]byte(`{"interval": 60, "threshold": 21.5}`), 10)
+	encoded := base64.RawStdEncoding.EncodeToString(payload)
+	receiver.fail["C:1,"+encoded[52:104]] = true
+	receiver.fail["E:"] = true
+
+	if err := device.CallFunctionChunked("config", payload, ChunkOptions{}); err != nil {
+		t.Fatalf("CallFunctionChunked(): %v", err)
+	}
+
+	if !bytes.Equal(receiver.payload, payload) {
+		t.Errorf("Device received %q, expected %q", receiver.payload, payload)
+	}
+
+	if expected := (len(encoded) + 51) / 52; receiver.chunks != expected {
+		t.Errorf("Payload was sent in %v chunks, expected %v", receiver.chunks, expected)
+	}
+
+	receiver.flip = true
+	receiver.ends = 0
+	err := device.CallFunctionChunked("config", payload, ChunkOptions{})
+
+	if !errors.Is(err, ErrChunkRejected) {
+		t.Errorf("CallFunctionChunked() returned %v, expected %v", err, ErrChunkRejected)
+	}
+
+	if receiver.ends != 1 {
+		t.Errorf("Rejected end of transfer was sent %v times, expected once", receiver.ends)
+	}
+}
@@ -1,11 +1,13 @@
 package particle
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // A Client manages the communication to the particle cloud.
@@ -21,6 +23,9 @@ type Client struct {
 
 	// Token for authentication.
 	Token string
+
+	// Limits the rate of requests, see SetRateLimit.
+	limiter *rateLimiter
 }
 
 // setHeaders sets the authorization and user-agent headers to the given request.
@@ -42,11 +47,23 @@ func NewClient(httpClient *http.Client, token string) *Client {
 		client:    httpClient,
 		BaseURL:   baseURL,
 		UserAgent: userAgent,
-		Token:     token}
+		Token:     token,
+		limiter:   &rateLimiter{}}
 
 	return c
 }
 
+// withTimeout returns a copy of the client, which limits the time of every request to the given timeout. The copy
+// shares the rate limit with the original client.
+func (c *Client) withTimeout(timeout time.Duration) *Client {
+	httpClient := *c.client
+	httpClient.Timeout = timeout
+	timed := *c
+	timed.client = &httpClient
+
+	return &timed
+}
+
 // newRequest creates a new http.Request with the given method to the given endPoint. This function will automatically
 // point the request to the clients baseURL, using the clients user agent and token. If a body is passed, than
 func (c *Client) newRequest(method, endPoint string, body io.Reader) (*http.Request, error) {
@@ -71,10 +88,44 @@ func (c *Client) newRequest(method, endPoint string, body io.Reader) (*http.Requ
 	return req, nil
 }
 
+// newFormRequest creates a new http.Request like newRequest, but with the given form values url encoded as body.
+func (c *Client) newFormRequest(method, endPoint string, form url.Values) (*http.Request, error) {
+	req, err := c.newRequest(method, endPoint, strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", mediaTypeForm)
+
+	return req, nil
+}
+
+// newJSONRequest creates a new http.Request like newRequest, but with the given value JSON encoded as body.
+func (c *Client) newJSONRequest(method, endPoint string, body interface{}) (*http.Request, error) {
+	data, err := json.Marshal(body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(method, endPoint, bytes.NewReader(data))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", mediaTypeJSON)
+
+	return req, nil
+}
+
 // do executes the given http.Request. If the interfaces v is passed, then the function tries to encode the JSON
 // response into that interface. The http.Response is passed regardless.
 func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
-	// Execute the request.
+	// Wait for the rate limit before executing the request.
+	c.limiter.wait()
+
 	resp, err := c.client.Do(req)
 
 	if err != nil {
@@ -121,13 +172,69 @@ func (c *Client) get(endPoint string, v interface{}) (*http.Response, error) {
 // passed, then the function tries to encode the JSON response into that interface. The http.Response is passed
 // regardless.
 func (c *Client) post(endPoint string, form url.Values, v interface{}) (*http.Response, error) {
-	req, err := c.newRequest("POST", endPoint, strings.NewReader(form.Encode()))
+	req, err := c.newFormRequest("POST", endPoint, form)
 
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", mediaTypeForm)
+	resp, err := c.do(req, v)
+
+	return resp, err
+}
+
+// delete executes a DELETE request to the given end point. If the interfaces v is passed, then the function tries to
+// encode the JSON response into that interface. The http.Response is passed regardless.
+func (c *Client) delete(endPoint string, v interface{}) (*http.Response, error) {
+	req, err := c.newRequest("DELETE", endPoint, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req, v)
+
+	return resp, err
+}
+
+// put executes a new PUT to the given end point with the given form values. If the interfaces v is passed, then the
+// function tries to encode the JSON response into that interface. The http.Response is passed regardless.
+func (c *Client) put(endPoint string, form url.Values, v interface{}) (*http.Response, error) {
+	req, err := c.newFormRequest("PUT", endPoint, form)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req, v)
+
+	return resp, err
+}
+
+// postJSON executes a new POST to the given end point with the given body encoded as JSON. If the interfaces v is
+// passed, then the function tries to encode the JSON response into that interface. The http.Response is passed
+// regardless.
+func (c *Client) postJSON(endPoint string, body, v interface{}) (*http.Response, error) {
+	req, err := c.newJSONRequest("POST", endPoint, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req, v)
+
+	return resp, err
+}
+
+// putJSON executes a new PUT to the given end point with the given body encoded as JSON. If the interfaces v is
+// passed, then the function tries to encode the JSON response into that interface. The http.Response is passed
+// regardless.
+func (c *Client) putJSON(endPoint string, body, v interface{}) (*http.Response, error) {
+	req, err := c.newJSONRequest("PUT", endPoint, body)
+
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := c.do(req, v)
 
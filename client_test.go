@@ -1,6 +1,7 @@
 package particle
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -163,3 +164,99 @@ func TestClient_Post(t *testing.T) {
 		t.Errorf("Response body = %v, expected %v", body, expected)
 	}
 }
+
+func TestClient_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if m := "DELETE"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+		fmt.Fprint(w, `{"ok": true}`)
+	})
+
+	body := struct{ Ok bool }{}
+	_, err := client.delete("/", &body)
+
+	if err != nil {
+		t.Fatalf("client.Delete(): %v", err)
+	}
+
+	if !body.Ok {
+		t.Errorf("Response body = %v, expected ok to be true", body)
+	}
+}
+
+func TestClient_Put(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if m := "PUT"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		if a := r.PostFormValue("action"); a != "activate" {
+			t.Errorf("Form value action = '%v', expected 'activate'", a)
+		}
+
+		fmt.Fprint(w, `{"ok": true}`)
+	})
+
+	form := url.Values{}
+	form.Add("action", "activate")
+
+	body := struct{ Ok bool }{}
+	_, err := client.put("/", form, &body)
+
+	if err != nil {
+		t.Fatalf("client.Put(): %v", err)
+	}
+
+	if !body.Ok {
+		t.Errorf("Response body = %v, expected ok to be true", body)
+	}
+}
+
+func TestClient_PostJSON(t *testing.T) {
+	setup()
+	defer teardown()
+
+	type foo struct {
+		Greeting string
+	}
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		if ct := r.Header.Get("Content-Type"); ct != mediaTypeJSON {
+			t.Errorf("Content-Type = %v, expected %v", ct, mediaTypeJSON)
+		}
+
+		body := foo{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Could not decode request body: %v", err)
+		}
+
+		if body.Greeting != "hello" {
+			t.Errorf("Request body greeting = '%v', expected 'hello'", body.Greeting)
+		}
+
+		fmt.Fprint(w, `{"Greeting": "world"}`)
+	})
+
+	body := new(foo)
+	_, err := client.postJSON("/", foo{"hello"}, &body)
+
+	if err != nil {
+		t.Fatalf("client.PostJSON(): %v", err)
+	}
+
+	expected := &foo{"world"}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("Response body = %v, expected %v", body, expected)
+	}
+}
@@ -0,0 +1,163 @@
+package particle
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Customer represents an end user of a product.
+type Customer struct {
+	ID             string
+	Username       string
+	ActivationCode string `json:"activation_code"`
+	Devices        []string
+}
+
+// Customers is an array of the Customer type.
+type Customers []Customer
+
+// customersResponse is a single page of customers as returned by the API.
+type customersResponse struct {
+	Customers Customers
+	Meta      struct {
+		TotalPages int `json:"total_pages"`
+	}
+}
+
+// customersURL returns the end point of the products customers.
+func (p *Product) customersURL() string {
+	return p.endPoint() + "/customers"
+}
+
+// CreateCustomer creates a new customer for the product with the given email and password and returns the access
+// token of the customer. The products OAuth client id and secret are needed for this.
+func (p *Product) CreateCustomer(clientID, clientSecret, email, password string) (AccessToken, error) {
+	form := url.Values{}
+	form.Add("email", email)
+	form.Add("password", password)
+
+	return p.postWithClientCredentials(p.customersURL(), clientID, clientSecret, form)
+}
+
+// CreateShadowCustomer creates a customer without a password, for products where the customers authentication is
+// handled by a separate system. The products OAuth client id and secret are needed for this. The access token of the
+// customer is returned.
+func (p *Product) CreateShadowCustomer(clientID, clientSecret, email string) (AccessToken, error) {
+	form := url.Values{}
+	form.Add("email", email)
+	form.Add("no_password", "true")
+
+	return p.postWithClientCredentials(p.customersURL(), clientID, clientSecret, form)
+}
+
+// postWithClientCredentials posts the form to the given end point, authenticated by the products OAuth client
+// credentials instead of the clients token, and returns the access token of the response.
+func (p *Product) postWithClientCredentials(endPoint, id, secret string, form url.Values) (AccessToken, error) {
+	var token AccessToken
+	req, err := p.client.newFormRequest("POST", endPoint, form)
+
+	if err != nil {
+		return token, err
+	}
+
+	req.SetBasicAuth(id, secret)
+	_, err = p.client.do(req, &token)
+
+	return token, err
+}
+
+// ListCustomers lists all customers of the product. The function follows the APIs pagination until every page has
+// been read.
+func (p *Product) ListCustomers() (Customers, error) {
+	var customers Customers
+
+	err := p.eachCustomersPage(func(page Customers) bool {
+		customers = append(customers, page...)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+// eachCustomersPage calls fn with every page of the products customers, until fn returns false or every page has been
+// read.
+func (p *Product) eachCustomersPage(fn func(page Customers) bool) error {
+	for page := 1; ; page++ {
+		var resp customersResponse
+		_, err := p.client.get(p.customersURL()+"?page="+strconv.Itoa(page), &resp)
+
+		if err != nil {
+			return err
+		}
+
+		if !fn(resp.Customers) || page >= resp.Meta.TotalPages {
+			return nil
+		}
+	}
+}
+
+// DeleteCustomer deletes the customer with the given email from the product.
+func (p *Product) DeleteCustomer(email string) error {
+	var resp okResponse
+	_, err := p.client.delete(p.customersURL()+"/"+url.PathEscape(email), &resp)
+
+	return err
+}
+
+// CreateCustomerToken generates an access token which is scoped to the customer with the given email. The products
+// OAuth client id and secret are needed for this. If expiresIn is 0, then the cloud's default lifetime is used.
+func (p *Product) CreateCustomerToken(clientID, clientSecret, email string, expiresIn int) (AccessToken, error) {
+	form := url.Values{}
+	form.Add("grant_type", "client_credentials")
+	form.Add("scope", "customer="+email)
+
+	if expiresIn > 0 {
+		form.Add("expires_in", strconv.Itoa(expiresIn))
+	}
+
+	return p.postWithClientCredentials(oauthTokenURL, clientID, clientSecret, form)
+}
+
+// CustomerDevices returns the devices which belong to the customer with the given email. The customers are only read
+// until the customer was found.
+func (p *Product) CustomerDevices(email string) (Devices, error) {
+	var customer *Customer
+
+	err := p.eachCustomersPage(func(page Customers) bool {
+		for idx := range page {
+			if page[idx].Username == email {
+				customer = &page[idx]
+				return false
+			}
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if customer == nil {
+		return nil, fmt.Errorf("customer %v not found in product %v", email, p.ID)
+	}
+
+	devices := make(Devices, 0, len(customer.Devices))
+
+	for _, id := range customer.Devices {
+		device, err := p.GetDevice(id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
@@ -0,0 +1,239 @@
+package particle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestProduct_CreateShadowCustomer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	product := generateTestProduct(1, "lamp")
+	email := "jane@example.com"
+
+	mux.HandleFunc(product.customersURL(), func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		if e := r.PostFormValue("email"); e != email {
+			t.Errorf("Post form value email = %v, expected %v", e, email)
+		}
+
+		if id, secret, ok := r.BasicAuth(); !ok || id != "client" || secret != "secret" {
+			t.Errorf("Basic auth = %v:%v, expected client:secret", id, secret)
+		}
+
+		if p := r.PostFormValue("no_password"); p != "true" {
+			t.Errorf("Post form value no_password = %v, expected true", p)
+		}
+
+		if _, ok := r.PostForm["password"]; ok {
+			t.Errorf("Shadow customers shouldn't send a password")
+		}
+
+		fmt.Fprint(w, `{"token_type": "bearer", "access_token": "abc", "expires_in": 7776000}`)
+	})
+
+	token, err := product.CreateShadowCustomer("client", "secret", email)
+
+	if err != nil {
+		t.Fatalf("CreateShadowCustomer(): %v", err)
+	}
+
+	expected := AccessToken{TokenType: "bearer", AccessToken: "abc", ExpiresIn: 7776000}
+	if token != expected {
+		t.Errorf("Token = %v, expected %v", token, expected)
+	}
+}
+
+func TestProduct_CreateCustomer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	product := generateTestProduct(1, "lamp")
+
+	mux.HandleFunc(product.customersURL(), func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != "client" || secret != "secret" {
+			t.Errorf("Basic auth = %v:%v, expected client:secret", id, secret)
+		}
+
+		if p := r.PostFormValue("password"); p != "hunter2" {
+			t.Errorf("Post form value password = %v, expected hunter2", p)
+		}
+
+		fmt.Fprint(w, `{"token_type": "bearer", "access_token": "abc", "expires_in": 7776000}`)
+	})
+
+	token, err := product.CreateCustomer("client", "secret", "jane@example.com", "hunter2")
+
+	if err != nil {
+		t.Fatalf("CreateCustomer(): %v", err)
+	}
+
+	if token.AccessToken != "abc" {
+		t.Errorf("Access token = %v, expected abc", token.AccessToken)
+	}
+}
+
+func TestProduct_ListCustomers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	product := generateTestProduct(1, "lamp")
+	pages := []Customers{
+		{{ID: "1", Username: "jane@example.com", Devices: []string{"a"}}},
+		{{ID: "2", Username: "joe@example.com"}},
+	}
+
+	mux.HandleFunc(product.customersURL(), func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		var resp customersResponse
+		resp.Meta.TotalPages = len(pages)
+
+		switch r.URL.Query().Get("page") {
+		case "1":
+			resp.Customers = pages[0]
+		case "2":
+			resp.Customers = pages[1]
+		default:
+			t.Errorf("Unexpected page %v requested", r.URL.Query().Get("page"))
+		}
+
+		err := json.NewEncoder(w).Encode(resp)
+
+		if err != nil {
+			t.Fatalf("Could not encode customers: %v", err)
+		}
+	})
+
+	customers, err := product.ListCustomers()
+
+	if err != nil {
+		t.Fatalf("ListCustomers(): %v", err)
+	}
+
+	expected := append(pages[0], pages[1]...)
+	if !reflect.DeepEqual(customers, expected) {
+		t.Errorf("Customers = %v, expected %v", customers, expected)
+	}
+}
+
+func TestProduct_DeleteCustomer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	product := generateTestProduct(1, "lamp")
+	called := false
+
+	mux.HandleFunc(product.customersURL()+"/jane@example.com", func(w http.ResponseWriter, r *http.Request) {
+		if m := "DELETE"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		called = true
+		fmt.Fprint(w, `{"ok": true}`)
+	})
+
+	err := product.DeleteCustomer("jane@example.com")
+
+	if err != nil {
+		t.Fatalf("DeleteCustomer(): %v", err)
+	}
+
+	if !called {
+		t.Errorf("DeleteCustomer() didn't call the API")
+	}
+}
+
+func TestProduct_CreateCustomerToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	product := generateTestProduct(1, "lamp")
+
+	mux.HandleFunc(oauthTokenURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		if id, secret, ok := r.BasicAuth(); !ok || id != "client" || secret != "secret" {
+			t.Errorf("Basic auth = %v:%v, expected client:secret", id, secret)
+		}
+
+		if s := r.PostFormValue("scope"); s != "customer=jane@example.com" {
+			t.Errorf("Post form value scope = %v, expected customer=jane@example.com", s)
+		}
+
+		if e := r.PostFormValue("expires_in"); e != "3600" {
+			t.Errorf("Post form value expires_in = %v, expected 3600", e)
+		}
+
+		fmt.Fprint(w, `{"token_type": "bearer", "access_token": "abc", "expires_in": 3600}`)
+	})
+
+	token, err := product.CreateCustomerToken("client", "secret", "jane@example.com", 3600)
+
+	if err != nil {
+		t.Fatalf("CreateCustomerToken(): %v", err)
+	}
+
+	if token.AccessToken != "abc" {
+		t.Errorf("Access token = %v, expected abc", token.AccessToken)
+	}
+}
+
+func TestProduct_CustomerDevices(t *testing.T) {
+	setup()
+	defer teardown()
+
+	product := generateTestProduct(1, "lamp")
+	device := generateTestDevice("a", "lamp", 6)
+
+	pages := 0
+
+	mux.HandleFunc(product.customersURL(), func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		resp := customersResponse{Customers: Customers{{ID: "1", Username: "jane@example.com", Devices: []string{"a"}}}}
+		resp.Meta.TotalPages = 2
+
+		err := json.NewEncoder(w).Encode(resp)
+
+		if err != nil {
+			t.Fatalf("Could not encode customers: %v", err)
+		}
+	})
+
+	mux.HandleFunc(product.endPoint()+"/devices/"+device.ID, func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(w).Encode(device)
+
+		if err != nil {
+			t.Fatalf("Could not encode device: %v", err)
+		}
+	})
+
+	devices, err := product.CustomerDevices("jane@example.com")
+
+	if err != nil {
+		t.Fatalf("CustomerDevices(): %v", err)
+	}
+
+	if !reflect.DeepEqual(devices, Devices{device}) {
+		t.Errorf("Devices = %v, expected %v", devices, Devices{device})
+	}
+
+	if pages != 1 {
+		t.Errorf("Read %v pages of customers, expected to stop after the first", pages)
+	}
+
+	if _, err := product.CustomerDevices("joe@example.com"); err == nil {
+		t.Errorf("CustomerDevices() for an unknown customer returned no error")
+	}
+}
@@ -2,34 +2,131 @@ package particle
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
+	"net"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 const deviceURL = "/v1/devices"
 
+// ErrUnknownFunction is returned when a function is called, which the device didn't declare.
+var ErrUnknownFunction = errors.New("function is not declared by the device")
+
+// ErrDeviceOffline is returned when a device couldn't be reached, because it isn't connected to the cloud.
+var ErrDeviceOffline = errors.New("device is offline")
+
+// ErrFunctionTimeout is returned when a device is connected, but didn't answer a function call in time.
+var ErrFunctionTimeout = errors.New("function call timed out")
+
 // Device information
 type Device struct {
-	ID            string
-	Name          string
-	LastApp       string `json:"last_app"`
-	LastIPAddress string `json:"last_ip_address"`
-	LastHeard     string `json:"last_heard"`
-	ProductID     byte   `json:"product_id"`
-	Connected     bool
-	Cellular      bool
-	Status        string
-	LastICCID     string `json:"last_iccid"`
-	IMEI          string
-	Variables     map[string]string
-	Functions     []string
-	client        *Client
+	ID                    string
+	Name                  string
+	LastApp               string     `json:"last_app"`
+	LastIPAddress         string     `json:"last_ip_address"`
+	LastHeard             *time.Time `json:"last_heard"`
+	LastHandshakeAt       *time.Time `json:"last_handshake_at"`
+	CreatedAt             *time.Time `json:"created_at"`
+	UpdatedAt             *time.Time `json:"updated_at"`
+	ProductID             int        `json:"product_id"`
+	PlatformID            Platform   `json:"platform_id"`
+	SystemFirmwareVersion string     `json:"system_firmware_version"`
+	Connected             bool
+	Cellular              bool
+	Status                string
+	LastICCID             string `json:"last_iccid"`
+	IMEI                  string
+	Groups                []string
+	Variables             map[string]string
+	Functions             []string
+	client                *Client
+	mu                    *sync.Mutex
 }
 
 // Devices is an array of the Device type.
 type Devices []Device
 
+// timestampLayouts are the layouts parseTimestamp tries, in order.
+var timestampLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05"}
+
+// parseTimestamp parses a JSON timestamp. Devices which were never heard of have null or empty timestamps, for which
+// nil is returned.
+func parseTimestamp(data json.RawMessage) (*time.Time, error) {
+	var str string
+
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(data, &str); err != nil {
+		return nil, err
+	}
+
+	if str == "" {
+		return nil, nil
+	}
+
+	var err error
+
+	for _, layout := range timestampLayouts {
+		var t time.Time
+		t, err = time.Parse(layout, str)
+
+		if err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, err
+}
+
+// UnmarshalJSON decodes a device, parsing it's timestamps with parseTimestamp.
+func (d *Device) UnmarshalJSON(data []byte) error {
+	type device Device
+
+	aux := struct {
+		*device
+		LastHeard       json.RawMessage `json:"last_heard"`
+		LastHandshakeAt json.RawMessage `json:"last_handshake_at"`
+		CreatedAt       json.RawMessage `json:"created_at"`
+		UpdatedAt       json.RawMessage `json:"updated_at"`
+	}{device: (*device)(d)}
+
+	err := json.Unmarshal(data, &aux)
+
+	if err != nil {
+		return err
+	}
+
+	timestamps := []struct {
+		field **time.Time
+		data  json.RawMessage
+	}{
+		{&d.LastHeard, aux.LastHeard},
+		{&d.LastHandshakeAt, aux.LastHandshakeAt},
+		{&d.CreatedAt, aux.CreatedAt},
+		{&d.UpdatedAt, aux.UpdatedAt},
+	}
+
+	for _, ts := range timestamps {
+		*ts.field, err = parseTimestamp(ts.data)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // FunctionResponse represents the response from the API after calling a device function.
 type FunctionResponse struct {
 	ID          string
@@ -69,6 +166,109 @@ func (c *Client) GetDevice(id string) (Device, error) {
 	return device, err
 }
 
+// Refresh reloads the device from the cloud, updating all of it's fields in place.
+func (d *Device) Refresh() error {
+	device, err := d.client.GetDevice(d.ID)
+
+	if err != nil {
+		return err
+	}
+
+	d.lock()
+	defer d.unlock()
+
+	device.mu = d.mu
+	*d = device
+
+	return nil
+}
+
+// KeepUpdated subscribes to the events of the device and updates Connected and LastHeard from them in the background,
+// until the returned EventListener is closed. spark/status events set whether the device is connected and any event
+// updates when the device was last heard. While the device is kept updated, ConnectionStatus has to be used to read
+// these fields. Errors are reported on the listeners ErrorChan, which has to be drained. This includes losing the
+// connection to the event stream, after which the device isn't updated anymore and KeepUpdated has to be called again.
+func (d *Device) KeepUpdated() (*EventListener, error) {
+	e, err := d.NewEventListener("")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if d.mu == nil {
+		d.mu = &sync.Mutex{}
+	}
+
+	// Refresh replaces the whole device, so the background updates must not read the mutex from it.
+	mu := d.mu
+
+	go func() {
+		if err := e.Listen(); err != nil {
+			select {
+			case e.ErrorChan <- err:
+			case <-e.done:
+			}
+		}
+	}()
+
+	go e.Handle(EventHandlerFunc(func(ev Event) {
+		d.applyEvent(mu, ev)
+	}))
+
+	return e, nil
+}
+
+// ConnectionStatus returns whether the device is connected and when it was last heard. The time is zero if the
+// device was never heard. Unlike reading the fields directly, it is safe to use while the device is kept updated.
+func (d *Device) ConnectionStatus() (connected bool, lastHeard time.Time) {
+	d.lock()
+	defer d.unlock()
+
+	if d.LastHeard != nil {
+		lastHeard = *d.LastHeard
+	}
+
+	return d.Connected, lastHeard
+}
+
+// applyEvent updates the devices connection status from the given event, holding the given device mutex.
+func (d *Device) applyEvent(mu *sync.Mutex, e Event) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if e.CoreID != "" && e.CoreID != d.ID {
+		return
+	}
+
+	if !e.PublishedAt.IsZero() {
+		publishedAt := e.PublishedAt
+		d.LastHeard = &publishedAt
+	}
+
+	if e.Name == "spark/status" {
+		switch e.Data {
+		case "online":
+			d.Connected = true
+		case "offline":
+			d.Connected = false
+		}
+	}
+}
+
+// lock locks the device, if it is kept updated in the background.
+func (d *Device) lock() {
+	if d.mu != nil {
+		d.mu.Lock()
+	}
+}
+
+// unlock unlocks the device, if it is kept updated in the background.
+func (d *Device) unlock() {
+	if d.mu != nil {
+		d.mu.Unlock()
+	}
+}
+
 // variableRaw returns the raw value from a variable as byte buffer for the given device ID and the given variable name.
 func (d *Device) variableRaw(name string) (*bytes.Buffer, error) {
 	resp, err := d.client.get(deviceURL+"/"+d.ID+"/"+name+"?format=raw", nil)
@@ -116,12 +316,88 @@ func (d *Device) VariableFloat(name string) (float64, error) {
 	return strconv.ParseFloat(str, 64)
 }
 
-// CallFunction calls the passed function name for the given and returns the function value.
-func (d *Device) CallFunction(name, argument string) (int, error) {
+// CallFunction calls the passed function name for the given device and returns the full response, which includes
+// the function value. If the devices functions are known, then the name is validated against them before calling it.
+// Devices which were listed as offline without their functions are refreshed first, to tell whether they're still
+// offline. Unknown functions, offline devices and timeouts are reported by wrapping ErrUnknownFunction,
+// ErrDeviceOffline and ErrFunctionTimeout, so they can be told apart from functions returning a negative value.
+func (d *Device) CallFunction(name, argument string) (FunctionResponse, error) {
+	connected, known, declared := d.functionState(name)
+
+	// Offline devices don't report their functions, so the connection has to be checked first.
+	if !known && !connected {
+		if err := d.Refresh(); err != nil {
+			return FunctionResponse{}, err
+		}
+
+		connected, known, declared = d.functionState(name)
+
+		if !connected {
+			return FunctionResponse{}, fmt.Errorf("calling %v on device %v: %w", name, d.ID, ErrDeviceOffline)
+		}
+	}
+
+	// Connected devices listed without their functions are validated by the API instead.
+	if known && !declared {
+		return FunctionResponse{}, fmt.Errorf("%v on device %v: %w", name, d.ID, ErrUnknownFunction)
+	}
+
 	form := url.Values{}
 	form.Add("arg", argument)
 	resp := FunctionResponse{}
 	_, err := d.client.post(deviceURL+"/"+d.ID+"/"+name, form, &resp)
 
-	return resp.ReturnValue, err
+	if err != nil {
+		return resp, functionError(d.ID, name, err)
+	}
+
+	if !resp.Connected {
+		return resp, fmt.Errorf("calling %v on device %v: %w", name, d.ID, ErrDeviceOffline)
+	}
+
+	return resp, nil
+}
+
+// functionState returns whether the device is connected, whether it's functions are known and whether it declared the
+// function with the given name. Unlike reading the fields directly, it is safe to use while the device is kept updated.
+func (d *Device) functionState(name string) (connected, known, declared bool) {
+	d.lock()
+	defer d.unlock()
+
+	for _, f := range d.Functions {
+		if f == name {
+			declared = true
+		}
+	}
+
+	return d.Connected, d.Functions != nil, declared
+}
+
+// functionError maps the API error of a function call to the matching sentinel error.
+func functionError(deviceID, name string, err error) error {
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return fmt.Errorf("calling %v on device %v: %v: %w", name, deviceID, err, ErrFunctionTimeout)
+	}
+
+	errResp, ok := err.(*ErrorResponse)
+
+	if !ok {
+		return err
+	}
+
+	msg := strings.ToLower(errResp.Message)
+	var sentinel error
+
+	switch {
+	case errResp.Response.StatusCode == http.StatusRequestTimeout || strings.Contains(msg, "timed out"):
+		sentinel = ErrFunctionTimeout
+	case strings.Contains(msg, "offline") || strings.Contains(msg, "not connected"):
+		sentinel = ErrDeviceOffline
+	case errResp.Response.StatusCode == http.StatusNotFound:
+		sentinel = ErrUnknownFunction
+	default:
+		return err
+	}
+
+	return fmt.Errorf("calling %v on device %v: %v: %w", name, deviceID, errResp.Message, sentinel)
 }
@@ -2,15 +2,17 @@ package particle
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 // generateTestDevice generates a device for testing.
-func generateTestDevice(id, name string, productID byte) Device {
+func generateTestDevice(id, name string, productID int) Device {
 	device := Device{
 		ID:        id,
 		Name:      name,
@@ -222,7 +224,7 @@ func TestDevice_CallFunction(t *testing.T) {
 		t.Fatalf("GetDevice(): %v", err)
 	}
 
-	if resp != 1 {
+	if resp.ReturnValue != 1 {
 		brew := 0
 
 		if funcArg == "coffee" {
@@ -232,3 +234,282 @@ func TestDevice_CallFunction(t *testing.T) {
 		t.Errorf("Response was '%v', althought '%v' was expected", resp, brew)
 	}
 }
+
+func TestDevice_CallFunctionErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "photon", 10)
+	device.Functions = []string{"brew", "grind", "clean", "fail"}
+
+	mux.HandleFunc(deviceURL+"/1/brew", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id": "1", "name": "brew", "connected": true, "return_value": -1}`)
+	})
+
+	mux.HandleFunc(deviceURL+"/1/grind", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"ok": false, "error": "Timed out."}`, http.StatusRequestTimeout)
+	})
+
+	mux.HandleFunc(deviceURL+"/1/clean", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"ok": false, "error": "Device is offline"}`, http.StatusBadRequest)
+	})
+
+	mux.HandleFunc(deviceURL+"/1/fail", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"ok": false, "error": "Permission denied"}`, http.StatusForbidden)
+	})
+
+	resp, err := device.CallFunction("brew", "")
+
+	if err != nil || resp.ReturnValue != -1 || !resp.Connected {
+		t.Errorf("CallFunction(brew) = %v, %v, expected a return value of -1", resp, err)
+	}
+
+	tests := []struct {
+		name     string
+		expected error
+	}{
+		{"grind", ErrFunctionTimeout},
+		{"clean", ErrDeviceOffline},
+		{"descale", ErrUnknownFunction},
+	}
+
+	for _, test := range tests {
+		if _, err := device.CallFunction(test.name, ""); !errors.Is(err, test.expected) {
+			t.Errorf("CallFunction(%v) returned %v, expected %v", test.name, err, test.expected)
+		}
+	}
+
+	_, err = device.CallFunction("fail", "")
+
+	if _, ok := err.(*ErrorResponse); !ok {
+		t.Errorf("CallFunction(fail) returned %v, expected the ErrorResponse", err)
+	}
+}
+
+func TestDevice_CallFunctionOffline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "photon", 10)
+
+	mux.HandleFunc(deviceURL+"/1", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id": "1", "connected": false, "functions": null}`)
+	})
+
+	mux.HandleFunc(deviceURL+"/1/brew", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Function of an offline device was called")
+	})
+
+	_, err := device.CallFunction("brew", "")
+
+	if !errors.Is(err, ErrDeviceOffline) {
+		t.Errorf("CallFunction() returned %v, expected %v", err, ErrDeviceOffline)
+	}
+}
+
+func TestDevice_PlatformAndProduct(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(deviceURL+"/1", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id": "1", "product_id": 1337, "platform_id": 13, "system_firmware_version": "1.5.2"}`)
+	})
+
+	device, err := client.GetDevice("1")
+
+	if err != nil {
+		t.Fatalf("GetDevice(): %v", err)
+	}
+
+	if device.ProductID != 1337 {
+		t.Errorf("Product id = %v, expected 1337", device.ProductID)
+	}
+
+	if device.PlatformID != PlatformBoron {
+		t.Errorf("Platform = %v, expected %v", device.PlatformID, PlatformBoron)
+	}
+
+	if device.SystemFirmwareVersion != "1.5.2" {
+		t.Errorf("System firmware version = %v, expected 1.5.2", device.SystemFirmwareVersion)
+	}
+}
+
+func TestDevice_Refresh(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "core", 0)
+	updated := device
+	updated.Connected = true
+	lastHeard := time.Date(2016, 8, 1, 12, 30, 0, 0, time.UTC)
+	updated.LastHeard = &lastHeard
+	updated.Functions = []string{"brew"}
+
+	mux.HandleFunc(deviceURL+"/"+device.ID, func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(w).Encode(updated)
+
+		if err != nil {
+			t.Fatalf("Could not encode device: %v", err)
+		}
+	})
+
+	if err := device.Refresh(); err != nil {
+		t.Fatalf("Refresh(): %v", err)
+	}
+
+	if !reflect.DeepEqual(device, updated) {
+		t.Errorf("Refreshed device %v doesn't match the updated one: %v", device, updated)
+	}
+}
+
+func TestDevice_KeepUpdated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "photon", 6)
+	publishedAt := time.Date(2016, 8, 1, 12, 30, 0, 0, time.UTC)
+
+	mux.HandleFunc(deviceURL+"/"+device.ID+"/events", func(w http.ResponseWriter, r *http.Request) {
+		data, err := json.Marshal(Event{Name: "spark/status", Data: "online", PublishedAt: publishedAt, CoreID: device.ID})
+
+		if err != nil {
+			t.Fatalf("Error while encoding event: %v", err)
+		}
+
+		fmt.Fprintf(w, "event: spark/status\n")
+		fmt.Fprintf(w, "data: %v\n\n", string(data))
+	})
+
+	e, err := device.KeepUpdated()
+
+	if err != nil {
+		t.Fatalf("KeepUpdated(): %v", err)
+	}
+
+	defer e.Close()
+
+	deadline := time.Now().Add(time.Second)
+
+	for {
+		connected, lastHeard := device.ConnectionStatus()
+
+		if connected && lastHeard.Equal(publishedAt) {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("Device wasn't updated, connected = %v, last heard = %v", connected, lastHeard)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDevice_KeepUpdatedDisconnect(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "photon", 6)
+
+	// The stream ends right away, like a dropped connection.
+	mux.HandleFunc(deviceURL+"/1/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, ":ok\n\n")
+	})
+
+	e, err := device.KeepUpdated()
+
+	if err != nil {
+		t.Fatalf("KeepUpdated(): %v", err)
+	}
+
+	defer e.Close()
+
+	select {
+	case err := <-e.ErrorChan:
+		if err == nil {
+			t.Error("KeepUpdated() reported a nil error for the lost connection")
+		}
+	case <-time.After(time.Second):
+		t.Error("KeepUpdated() didn't report the lost connection")
+	}
+}
+
+func TestDevice_KeepUpdatedConcurrently(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "photon", 6)
+	device.Connected = true
+
+	mux.HandleFunc(deviceURL+"/1/events", func(w http.ResponseWriter, r *http.Request) {
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(time.Millisecond):
+			}
+
+			fmt.Fprintf(w, "event: spark/status\ndata: {\"name\": \"spark/status\", \"data\": \"online\", \"coreid\": \"1\"}\n\n")
+			w.(http.Flusher).Flush()
+		}
+	})
+
+	mux.HandleFunc(deviceURL+"/1", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id": "1", "connected": true, "functions": ["brew"], "variables": {"temp": "double"}}`)
+	})
+
+	mux.HandleFunc(deviceURL+"/1/brew", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id": "1", "connected": true, "return_value": 1}`)
+	})
+
+	e, err := device.KeepUpdated()
+
+	if err != nil {
+		t.Fatalf("KeepUpdated(): %v", err)
+	}
+
+	defer e.Close()
+
+	for i := 0; i < 10; i++ {
+		if _, err := device.CallFunction("brew", ""); err != nil {
+			t.Errorf("CallFunction(): %v", err)
+		}
+
+		if _, err := device.VariableInfo("temp"); err != nil {
+			t.Errorf("VariableInfo(): %v", err)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDevice_UnmarshalJSON(t *testing.T) {
+	var device Device
+
+	err := json.Unmarshal([]byte(`{"id": "1", "name": "core", "last_heard": null, "last_handshake_at": "",
+		"created_at": "2016-08-01T12:30:00.000Z", "updated_at": "2016-08-02 08:00:00"}`), &device)
+
+	if err != nil {
+		t.Fatalf("Could not decode device: %v", err)
+	}
+
+	if device.ID != "1" || device.Name != "core" {
+		t.Errorf("Device = %v, expected core with id 1", device)
+	}
+
+	if device.LastHeard != nil || device.LastHandshakeAt != nil {
+		t.Errorf("Empty timestamps decoded as %v and %v, expected nil", device.LastHeard, device.LastHandshakeAt)
+	}
+
+	if c := time.Date(2016, 8, 1, 12, 30, 0, 0, time.UTC); device.CreatedAt == nil || !device.CreatedAt.Equal(c) {
+		t.Errorf("Created at = %v, expected %v", device.CreatedAt, c)
+	}
+
+	if u := time.Date(2016, 8, 2, 8, 0, 0, 0, time.UTC); device.UpdatedAt == nil || !device.UpdatedAt.Equal(u) {
+		t.Errorf("Updated at = %v, expected %v", device.UpdatedAt, u)
+	}
+
+	if err := json.Unmarshal([]byte(`{"last_heard": "yesterday"}`), &device); err == nil {
+		t.Errorf("Decoding an invalid timestamp returned no error")
+	}
+}
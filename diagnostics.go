@@ -0,0 +1,191 @@
+package particle
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+const diagnosticsURL = "/v1/diagnostics"
+
+// DiagnosticValue is a single numeric vital. Vitals the device couldn't determine are reported with an error code
+// instead of a value, vitals the device didn't report at all aren't set.
+type DiagnosticValue struct {
+	Value float64
+	Err   int
+	// set is true if the vital was part of the payload.
+	set bool
+}
+
+// UnmarshalJSON decodes either a plain number or an error object like {"err": -210}.
+func (v *DiagnosticValue) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var e struct {
+			Err int
+		}
+
+		err := json.Unmarshal(data, &e)
+		*v = DiagnosticValue{Err: e.Err, set: true}
+
+		return err
+	}
+
+	if string(data) == "null" {
+		return nil
+	}
+
+	*v = DiagnosticValue{set: true}
+
+	return json.Unmarshal(data, &v.Value)
+}
+
+// Valid reports whether the device reported the vital and was able to determine it's value.
+func (v DiagnosticValue) Valid() bool {
+	return v.set && v.Err == 0
+}
+
+// SignalVitals is the strength and quality of the devices network signal.
+type SignalVitals struct {
+	At            string
+	Strength      DiagnosticValue
+	StrengthUnits string `json:"strength_units"`
+	Quality       DiagnosticValue
+	QualityUnits  string `json:"quality_units"`
+}
+
+// ConnectionVitals describes the state of a network or cloud connection.
+type ConnectionVitals struct {
+	Status           string
+	Error            DiagnosticValue
+	Attempts         DiagnosticValue
+	Disconnects      DiagnosticValue
+	DisconnectReason string `json:"disconnect_reason"`
+}
+
+// CellularVitals describes the cellular network of the device.
+type CellularVitals struct {
+	RadioAccessTechnology string `json:"radio_access_technology"`
+	Operator              string
+}
+
+// NetworkVitals describes the devices network connection.
+type NetworkVitals struct {
+	Signal     SignalVitals
+	Connection ConnectionVitals
+	Cellular   *CellularVitals
+}
+
+// CoAPVitals are the message counters of the devices cloud protocol.
+type CoAPVitals struct {
+	Transmit   DiagnosticValue
+	Retransmit DiagnosticValue
+	Unack      DiagnosticValue
+	RoundTrip  DiagnosticValue `json:"round_trip"`
+}
+
+// CloudVitals describes the devices connection to the particle cloud.
+type CloudVitals struct {
+	Connection ConnectionVitals
+	CoAP       CoAPVitals `json:"coap"`
+	Publish    struct {
+		RateLimited DiagnosticValue `json:"rate_limited"`
+	}
+}
+
+// SystemVitals describes the uptime and memory usage of the device.
+type SystemVitals struct {
+	Uptime DiagnosticValue
+	Memory struct {
+		Used  DiagnosticValue
+		Total DiagnosticValue
+	}
+}
+
+// PowerVitals describes the power source and battery of the device.
+type PowerVitals struct {
+	Source  string
+	Battery struct {
+		Charge DiagnosticValue
+		State  string
+	}
+}
+
+// DeviceVitals are the vitals reported by the device itself.
+type DeviceVitals struct {
+	Network NetworkVitals
+	Cloud   CloudVitals
+	System  SystemVitals
+	Power   PowerVitals
+}
+
+// ServiceVitals are the vitals of the device as seen by the particle cloud.
+type ServiceVitals struct {
+	Device struct {
+		Status string
+	}
+	Cloud struct {
+		Uptime  DiagnosticValue
+		Publish struct {
+			Sent DiagnosticValue
+		}
+	}
+}
+
+// Diagnostics is a single diagnostics report of a device.
+type Diagnostics struct {
+	DeviceID  string    `json:"deviceID"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Payload   struct {
+		Device  DeviceVitals
+		Service ServiceVitals
+	}
+}
+
+// Diagnostics returns the last known diagnostics of the device.
+func (d *Device) Diagnostics() (Diagnostics, error) {
+	var resp struct {
+		Diagnostics Diagnostics
+	}
+
+	_, err := d.client.get(diagnosticsURL+"/"+d.ID+"/last", &resp)
+
+	return resp.Diagnostics, err
+}
+
+// RefreshDiagnostics asks the device to send fresh diagnostics. The device has to be online, the new report can be
+// retrieved with Diagnostics once the device has sent it.
+func (d *Device) RefreshDiagnostics() error {
+	var resp okResponse
+	_, err := d.client.post(diagnosticsURL+"/"+d.ID+"/update", nil, &resp)
+
+	return err
+}
+
+// DiagnosticsHistory returns the diagnostics reports of the device in the given time range. Zero times leave the
+// range open on that side.
+func (d *Device) DiagnosticsHistory(start, end time.Time) ([]Diagnostics, error) {
+	query := url.Values{}
+
+	if !start.IsZero() {
+		query.Add("start_date", start.UTC().Format(time.RFC3339))
+	}
+
+	if !end.IsZero() {
+		query.Add("end_date", end.UTC().Format(time.RFC3339))
+	}
+
+	endPoint := diagnosticsURL + "/" + d.ID
+
+	if len(query) > 0 {
+		endPoint += "?" + query.Encode()
+	}
+
+	var resp struct {
+		Diagnostics []Diagnostics
+	}
+
+	_, err := d.client.get(endPoint, &resp)
+
+	return resp.Diagnostics, err
+}
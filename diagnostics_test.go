@@ -0,0 +1,131 @@
+package particle
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testDiagnostics = `{"deviceID": "1", "updated_at": "2016-08-01T12:30:00.000Z", "payload": {
+	"device": {
+		"network": {"signal": {"strength": 82.5, "strength_units": "%", "quality": {"err": -210}},
+			"connection": {"status": "connected", "disconnects": 2}},
+		"cloud": {"connection": {"status": "connected", "disconnects": 1, "disconnect_reason": "error"},
+			"coap": {"round_trip": 420}},
+		"system": {"uptime": 3600, "memory": {"used": 50000, "total": 80000}},
+		"power": {"source": "battery", "battery": {"charge": 64.2, "state": "discharging"}}
+	},
+	"service": {"device": {"status": "ok"}}
+}}`
+
+func TestDevice_Diagnostics(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "electron", 10)
+
+	mux.HandleFunc(diagnosticsURL+"/"+device.ID+"/last", func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		fmt.Fprintf(w, `{"diagnostics": %v}`, testDiagnostics)
+	})
+
+	diag, err := device.Diagnostics()
+
+	if err != nil {
+		t.Fatalf("Diagnostics(): %v", err)
+	}
+
+	vitals := diag.Payload.Device
+
+	if s := vitals.Network.Signal.Strength; !s.Valid() || s.Value != 82.5 {
+		t.Errorf("Signal strength = %v, expected 82.5", s)
+	}
+
+	if q := vitals.Network.Signal.Quality; q.Valid() || q.Err != -210 {
+		t.Errorf("Signal quality = %v, expected error -210", q)
+	}
+
+	if a := vitals.Network.Connection.Attempts; a.Valid() {
+		t.Errorf("Connection attempts = %v, expected the missing vital to be invalid", a)
+	}
+
+	if c := vitals.Power.Battery.Charge.Value; c != 64.2 {
+		t.Errorf("Battery charge = %v, expected 64.2", c)
+	}
+
+	if m := vitals.System.Memory.Used.Value; m != 50000 {
+		t.Errorf("Used memory = %v, expected 50000", m)
+	}
+
+	if d := vitals.Cloud.Connection.Disconnects.Value; d != 1 {
+		t.Errorf("Cloud disconnects = %v, expected 1", d)
+	}
+
+	if s := diag.Payload.Service.Device.Status; s != "ok" {
+		t.Errorf("Service status = %v, expected ok", s)
+	}
+
+	if u := time.Date(2016, 8, 1, 12, 30, 0, 0, time.UTC); !diag.UpdatedAt.Equal(u) {
+		t.Errorf("Updated at = %v, expected %v", diag.UpdatedAt, u)
+	}
+}
+
+func TestDevice_RefreshDiagnostics(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "electron", 10)
+	called := false
+
+	mux.HandleFunc(diagnosticsURL+"/"+device.ID+"/update", func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		called = true
+		fmt.Fprint(w, `{"ok": true}`)
+	})
+
+	if err := device.RefreshDiagnostics(); err != nil {
+		t.Fatalf("RefreshDiagnostics(): %v", err)
+	}
+
+	if !called {
+		t.Errorf("RefreshDiagnostics() didn't call the API")
+	}
+}
+
+func TestDevice_DiagnosticsHistory(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "electron", 10)
+	start := time.Date(2016, 8, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	mux.HandleFunc(diagnosticsURL+"/"+device.ID, func(w http.ResponseWriter, r *http.Request) {
+		if s := r.URL.Query().Get("start_date"); s != "2016-08-01T00:00:00Z" {
+			t.Errorf("Start date = %v, expected 2016-08-01T00:00:00Z", s)
+		}
+
+		if e := r.URL.Query().Get("end_date"); e != "2016-08-02T00:00:00Z" {
+			t.Errorf("End date = %v, expected 2016-08-02T00:00:00Z", e)
+		}
+
+		fmt.Fprintf(w, `{"diagnostics": [%v, %v]}`, testDiagnostics, testDiagnostics)
+	})
+
+	history, err := device.DiagnosticsHistory(start, end)
+
+	if err != nil {
+		t.Fatalf("DiagnosticsHistory(): %v", err)
+	}
+
+	if len(history) != 2 {
+		t.Errorf("Got %v reports, expected 2", len(history))
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -27,6 +31,20 @@ type Event struct {
 	Data        string
 	TTL         string
 	PublishedAt time.Time `json:"published_at"`
+	CoreID      string    `json:"coreid"`
+}
+
+// EventHandler handles events, regardless whether they were streamed by an EventListener or received from a webhook.
+type EventHandler interface {
+	HandleEvent(e Event)
+}
+
+// EventHandlerFunc is an adapter to allow the use of ordinary functions as EventHandler.
+type EventHandlerFunc func(e Event)
+
+// HandleEvent calls f(e).
+func (f EventHandlerFunc) HandleEvent(e Event) {
+	f(e)
 }
 
 // EventListener listens to events from the particle cloud api and outputs them over the OutputChan channel.
@@ -34,7 +52,11 @@ type EventListener struct {
 	OutputChan EventChannel
 	ErrorChan  ErrorChannel
 	response   *http.Response
-	running    bool
+	// started is set once Listen was called, it's guarded by mu.
+	started   bool
+	mu        sync.Mutex
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 // newEventListener creates a new EventListener + its channels but doesn't connects to the server
@@ -43,6 +65,7 @@ func newEventListener() *EventListener {
 
 	e.OutputChan = make(chan Event)
 	e.ErrorChan = make(chan error)
+	e.done = make(chan struct{})
 
 	return e
 }
@@ -128,18 +151,52 @@ func (d *Device) NewEventListener(name string) (*EventListener, error) {
 	return e, nil
 }
 
-// Listen starts reading events from the cloud API.
+// PublishEvent publishes an event with the given name and data. Private events are only sent to the devices and
+// subscribers of the tokens account.
+func (c *Client) PublishEvent(name, data string, private bool) error {
+	form := url.Values{}
+	form.Add("name", name)
+	form.Add("data", data)
+	form.Add("private", strconv.FormatBool(private))
+	var resp okResponse
+	_, err := c.post(deviceURL+"/events", form, &resp)
+
+	return err
+}
+
+// Listen starts reading events from the cloud API. The OutputChan is closed once Listen returns, either because the
+// EventListener was closed or because the connection was lost. Listen may only be called once per EventListener.
 func (e *EventListener) Listen() error {
+	e.mu.Lock()
+
+	if e.started {
+		e.mu.Unlock()
+		return errors.New("EventListener is already listening")
+	}
+
+	// Close already closed the OutputChan, because Listen wasn't running yet.
+	if e.closed() {
+		e.mu.Unlock()
+		return nil
+	}
+
+	e.started = true
+	e.mu.Unlock()
+
 	ev := Event{}
 	reader := bufio.NewReader(e.response.Body)
 	var buf bytes.Buffer
 
-	e.running = true
+	defer close(e.OutputChan)
 
-	for e.running {
+	for !e.closed() {
 		line, err := reader.ReadBytes('\n')
 
 		if err != nil {
+			if e.closed() {
+				return nil
+			}
+
 			return fmt.Errorf("Error while reading line: %v", err)
 		}
 
@@ -156,9 +213,17 @@ func (e *EventListener) Listen() error {
 				err := json.Unmarshal(b, &ev)
 
 				if err == nil {
-					e.OutputChan <- ev
+					select {
+					case e.OutputChan <- ev:
+					case <-e.done:
+						return nil
+					}
 				} else {
-					e.ErrorChan <- err
+					select {
+					case e.ErrorChan <- err:
+					case <-e.done:
+						return nil
+					}
 				}
 
 				buf.Reset()
@@ -170,8 +235,38 @@ func (e *EventListener) Listen() error {
 	return nil
 }
 
-// Close closes the EventListeners channel and stops the listening loop.
+// Handle passes every event from the OutputChan to the given handler until the EventListener is closed.
+func (e *EventListener) Handle(h EventHandler) {
+	for ev := range e.OutputChan {
+		h.HandleEvent(ev)
+	}
+}
+
+// closed reports whether Close was called.
+func (e *EventListener) closed() bool {
+	select {
+	case <-e.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close stops the listening loop and disconnects from the server. The OutputChan gets closed as soon as the loop
+// has stopped, or right away if Listen was never called. Close may be called multiple times.
 func (e *EventListener) Close() {
-	close(e.OutputChan)
-	e.running = false
+	e.closeOnce.Do(func() {
+		close(e.done)
+
+		if e.response != nil {
+			e.response.Body.Close()
+		}
+
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		if !e.started {
+			close(e.OutputChan)
+		}
+	})
 }
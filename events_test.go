@@ -34,7 +34,7 @@ func TestClient_NewEventListener(t *testing.T) {
 		t.Errorf("The EventListeners response is nil.")
 	}
 
-	if e.running {
+	if e.started {
 		t.Errorf("EventListener is already listening although .listen wasn't called.")
 	}
 }
@@ -43,7 +43,7 @@ func TestEventListener_Listen(t *testing.T) {
 	setup()
 	defer teardown()
 
-	e := Event{"greeting", "Hello, World", "60", time.Now()}
+	e := Event{"greeting", "Hello, World", "60", time.Now(), "1"}
 
 	mux.HandleFunc(eventURL, func(w http.ResponseWriter, r *http.Request) {
 		if m := "GET"; r.Method != m {
@@ -83,7 +83,7 @@ func TestEventListener_ListenDevice(t *testing.T) {
 
 	d := generateTestDevice("1", "photon", 0)
 
-	e := Event{"greeting", "Hello, World", "60", time.Now()}
+	e := Event{"greeting", "Hello, World", "60", time.Now(), "1"}
 
 	mux.HandleFunc(deviceURL+"/"+d.ID+"/events/"+e.Name, func(w http.ResponseWriter, r *http.Request) {
 		if m := "GET"; r.Method != m {
@@ -111,7 +111,7 @@ func TestEventListener_ListenDevice(t *testing.T) {
 
 	go func() {
 		for err := range eventLister.ErrorChan {
-			t.Fatalf("Received error from EventListener: %v", err)
+			t.Errorf("Received error from EventListener: %v", err)
 		}
 	}()
 
@@ -122,3 +122,78 @@ func TestEventListener_ListenDevice(t *testing.T) {
 		eventLister.Close()
 	}
 }
+
+func TestEventListener_Handle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	e := Event{"greeting", "Hello, World", "60", time.Now(), "1"}
+
+	mux.HandleFunc(eventURL, func(w http.ResponseWriter, r *http.Request) {
+		data, err := json.Marshal(e)
+
+		if err != nil {
+			t.Fatalf("Error while encoding event: %v", err)
+		}
+
+		fmt.Fprintf(w, "event: %v\n", e.Name)
+		fmt.Fprintf(w, "data: %v\n\n", string(data[:]))
+	})
+
+	eventLister, err := client.NewEventListener("")
+
+	if err != nil {
+		t.Fatalf("Error while creating EventLister: %v", err)
+	}
+
+	go eventLister.Listen()
+
+	eventLister.Handle(EventHandlerFunc(func(event Event) {
+		if event.Name != e.Name || event.Data != e.Data || event.CoreID != e.CoreID {
+			t.Errorf("Got event %v, expected %v", event, e)
+		}
+		eventLister.Close()
+	}))
+}
+
+func TestEventListener_Close(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(eventURL, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, ":ok\n\n")
+	})
+
+	eventLister, err := client.NewEventListener("")
+
+	if err != nil {
+		t.Fatalf("Error while creating EventLister: %v", err)
+	}
+
+	eventLister.Close()
+
+	for event := range eventLister.OutputChan {
+		t.Errorf("Got event %v from a closed EventListener", event)
+	}
+
+	if err := eventLister.Listen(); err != nil {
+		t.Errorf("Listen() on a closed EventListener returned %v", err)
+	}
+
+	eventLister, err = client.NewEventListener("")
+
+	if err != nil {
+		t.Fatalf("Error while creating EventLister: %v", err)
+	}
+
+	go eventLister.Listen()
+	defer eventLister.Close()
+
+	// Wait for the listener to start, it closes the OutputChan once the stream ended.
+	for range eventLister.OutputChan {
+	}
+
+	if err := eventLister.Listen(); err == nil {
+		t.Error("Listen() was started twice")
+	}
+}
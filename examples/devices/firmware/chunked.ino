@@ -0,0 +1,145 @@
+// Reference implementation of the device side of Device.CallFunctionChunked. The payload is reassembled in a fixed
+// buffer and handed to applyConfig once the transfer ended and the checksum matched.
+#define MAX_PAYLOAD 2048
+
+uint8_t payload[MAX_PAYLOAD];
+size_t payloadSize = 0;
+size_t received = 0;
+int chunks = 0;
+int nextChunk = 0;
+uint32_t checksum = 0;
+
+int receiveConfig(String command);
+
+void setup() {
+    Particle.function("config", receiveConfig);
+}
+
+void loop() {
+    delay(1000);
+}
+
+void applyConfig(const uint8_t *data, size_t size) {
+    // Parse and store the configuration here.
+}
+
+uint32_t crc32(const uint8_t *data, size_t size) {
+    uint32_t crc = 0xFFFFFFFF;
+
+    for (size_t i = 0; i < size; i++) {
+        crc ^= data[i];
+
+        for (int bit = 0; bit < 8; bit++) {
+            crc = (crc >> 1) ^ (0xEDB88320 & -(crc & 1));
+        }
+    }
+
+    return ~crc;
+}
+
+int base64Value(char c) {
+    if (c >= 'A' && c <= 'Z') return c - 'A';
+    if (c >= 'a' && c <= 'z') return c - 'a' + 26;
+    if (c >= '0' && c <= '9') return c - '0' + 52;
+    if (c == '+') return 62;
+    if (c == '/') return 63;
+    return -1;
+}
+
+// appendBase64 decodes the unpadded base64 data and appends it to the payload.
+bool appendBase64(const char *data) {
+    uint32_t bits = 0;
+    int count = 0;
+
+    for (; *data; data++) {
+        int value = base64Value(*data);
+
+        if (value < 0) {
+            return false;
+        }
+
+        bits = (bits << 6) | value;
+        count += 6;
+
+        if (count >= 8) {
+            count -= 8;
+
+            if (received >= payloadSize) {
+                return false;
+            }
+
+            payload[received++] = (bits >> count) & 0xFF;
+        }
+    }
+
+    return true;
+}
+
+// receiveConfig handles the messages of a chunked transfer:
+// "S:<chunks>,<size>,<crc32>" starts it, "C:<seq>,<data>" sends a chunk and "E:" ends it.
+int receiveConfig(String command) {
+    const char *arg = command.c_str();
+
+    if (command.startsWith("S:")) {
+        unsigned long size, crc;
+        int count;
+
+        if (sscanf(arg + 2, "%d,%lu,%lx", &count, &size, &crc) != 3) {
+            return -1;
+        }
+
+        if (size > MAX_PAYLOAD) {
+            return -5;
+        }
+
+        chunks = count;
+        payloadSize = size;
+        checksum = crc;
+        received = 0;
+        nextChunk = 0;
+
+        return 0;
+    }
+
+    if (command.startsWith("C:")) {
+        int comma = command.indexOf(',');
+
+        if (comma < 0) {
+            return -1;
+        }
+
+        int seq = command.substring(2, comma).toInt();
+
+        // The chunk was received before, but the answer got lost.
+        if (seq == nextChunk - 1) {
+            return seq;
+        }
+
+        if (seq != nextChunk || seq >= chunks) {
+            return -2;
+        }
+
+        size_t before = received;
+
+        if (!appendBase64(arg + comma + 1)) {
+            received = before;
+            return -1;
+        }
+
+        nextChunk++;
+
+        return seq;
+    }
+
+    if (command.startsWith("E:")) {
+        if (nextChunk != chunks || received != payloadSize || crc32(payload, received) != checksum) {
+            return -3;
+        }
+
+        applyConfig(payload, received);
+
+        return 0;
+    }
+
+    return -1;
+}
@@ -52,5 +52,5 @@ func main() {
 		common.PrintError(err)
 	}
 
-	fmt.Printf("The function %v returned the value %v\n", funcName, result)
+	fmt.Printf("The function %v returned the value %v\n", funcName, result.ReturnValue)
 }
@@ -18,7 +18,7 @@ func main() {
 	flag.StringVar(&deviceID, "d", "", "Set the device id (shorthand)")
 	flag.StringVar(&variable, "variable", "", "Set the variable name to retrieve")
 	flag.StringVar(&variable, "v", "", "Set the variable name to retrieve (shorthand)")
-	flag.StringVar(&varType, "type", "string", "Set the expected type of the variable value.")
+	flag.StringVar(&varType, "type", "auto", "Set the expected type of the variable value.")
 
 	flag.Usage = func() {
 		fmt.Println("variables -t [token] -d [deviceID] -v [variable_name]")
@@ -48,6 +48,14 @@ func main() {
 	}
 
 	switch varType {
+	case "auto":
+		value, err := d.Variable(variable)
+		if err != nil {
+			common.PrintError(err)
+		}
+
+		fmt.Printf("Value for '%v' from device '%v' is '%v' with the declared type '%v'.\n", variable, d.Name,
+			value, value.Type)
 	case "string":
 		value, err := d.VariableString(variable)
 		if err != nil {
@@ -73,7 +81,7 @@ func main() {
 		fmt.Printf("Value for '%v' from device '%v' is '%v' with the type '%v'.\n", variable, d.Name, value,
 			reflect.TypeOf(value))
 	default:
-		msg := fmt.Sprintf("The passed type '%v' is not supported. Please use 'string', 'int' or 'float'",
+		msg := fmt.Sprintf("The passed type '%v' is not supported. Please use 'auto', 'string', 'int' or 'float'",
 			varType)
 		common.UsageAndExit(msg, 1, flag.Usage)
 	}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/mitchellh/colorstring"
+	"github.com/sepal/particle"
+	"github.com/sepal/particle/examples/common"
+	"os"
+)
+
+var token, file string
+var apply bool
+
+// Sync the integrations of an account or product with the ones described in a YAML or JSON file.
+func main() {
+	flag.StringVar(&token, "token", "", "Set the authentication token")
+	flag.StringVar(&token, "t", "", "Set the authentication token (shorthand)")
+	flag.StringVar(&file, "file", "", "Set the YAML or JSON file describing the integrations")
+	flag.StringVar(&file, "f", "", "Set the YAML or JSON file describing the integrations (shorthand)")
+	flag.BoolVar(&apply, "apply", false, "Apply the changes instead of only printing the plan")
+
+	flag.Usage = func() {
+		fmt.Println("sync -t token -f integrations.yaml [-apply]")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if token == "" {
+		common.UsageAndExit("Please set a token.", 0, flag.Usage)
+	}
+
+	if file == "" {
+		common.UsageAndExit("Please set a file describing the integrations.", 0, flag.Usage)
+	}
+
+	f, err := os.Open(file)
+
+	if err != nil {
+		common.PrintError(err)
+	}
+
+	defer f.Close()
+
+	config, err := particle.LoadIntegrationConfig(f)
+
+	if err != nil {
+		common.PrintError(err)
+	}
+
+	c := particle.NewClient(nil, token)
+
+	m, err := config.Manager(c)
+
+	if err != nil {
+		common.PrintError(err)
+	}
+
+	plan, err := particle.PlanIntegrationSync(m, config.Integrations)
+
+	if err != nil {
+		common.PrintError(err)
+	}
+
+	fmt.Print(plan)
+
+	if !apply || len(plan) == 0 {
+		return
+	}
+
+	err = plan.Apply(m)
+
+	if err != nil {
+		common.PrintError(err)
+	}
+
+	fmt.Println(colorstring.Color("[green]Integrations are up to date."))
+}
@@ -0,0 +1,321 @@
+package particle
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// defaultWorkers is the number of concurrent requests of fleet operations, if no other number was set.
+const defaultWorkers = 10
+
+// BatchOptions configures how fleet operations run their requests.
+type BatchOptions struct {
+	// Workers is the maximum number of concurrent requests, defaults to 10. The overall request rate is bound by the
+	// clients rate limit, see Client.SetRateLimit.
+	Workers int
+	// SkipOffline skips devices which aren't connected instead of trying to reach them.
+	SkipOffline bool
+}
+
+// workers returns the number of workers to use.
+func (opts BatchOptions) workers() int {
+	if opts.Workers > 0 {
+		return opts.Workers
+	}
+
+	return defaultWorkers
+}
+
+// DeviceVariables holds the variables read from a single device by BatchReadVariables.
+type DeviceVariables struct {
+	DeviceID string
+	// Values maps the names of the successfully read variables to their raw values.
+	Values map[string]string
+	// Errors maps the names of the variables which couldn't be read to their error.
+	Errors map[string]error
+	// Skipped is true if the device was offline and BatchOptions.SkipOffline was set.
+	Skipped bool
+}
+
+// BatchReadResult is the result of BatchReadVariables, with one entry per device in the order of the devices.
+type BatchReadResult []DeviceVariables
+
+// Failed returns the results of the devices for which at least one variable couldn't be read.
+func (r BatchReadResult) Failed() BatchReadResult {
+	var failed BatchReadResult
+
+	for _, vars := range r {
+		if len(vars.Errors) > 0 {
+			failed = append(failed, vars)
+		}
+	}
+
+	return failed
+}
+
+// runWorkers calls job for every index in [0, n) using the given number of concurrent workers.
+func runWorkers(n, workers int, job func(idx int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for idx := range jobs {
+				job(idx)
+			}
+		}()
+	}
+
+	for idx := 0; idx < n; idx++ {
+		jobs <- idx
+	}
+
+	close(jobs)
+	wg.Wait()
+}
+
+// BatchReadVariables reads the variables with the given names from all devices using a bounded number of concurrent
+// requests. Errors are collected per device and variable instead of aborting the whole batch.
+func (devices Devices) BatchReadVariables(names []string, opts BatchOptions) BatchReadResult {
+	result := make(BatchReadResult, len(devices))
+	var mu sync.Mutex
+
+	type job struct {
+		device int
+		name   string
+	}
+
+	var jobs []job
+
+	for idx := range devices {
+		d := &devices[idx]
+		result[idx] = DeviceVariables{DeviceID: d.ID, Values: map[string]string{}, Errors: map[string]error{}}
+
+		if opts.SkipOffline && !d.Connected {
+			result[idx].Skipped = true
+			continue
+		}
+
+		for _, name := range names {
+			jobs = append(jobs, job{idx, name})
+		}
+	}
+
+	runWorkers(len(jobs), opts.workers(), func(idx int) {
+		j := jobs[idx]
+		value, err := devices[j.device].VariableString(j.name)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			result[j.device].Errors[j.name] = err
+		} else {
+			result[j.device].Values[j.name] = value
+		}
+	})
+
+	return result
+}
+
+// Select returns the devices for which the given function returns true, e.g. to call a function on the devices of a
+// single group only.
+func (devices Devices) Select(fn func(d Device) bool) Devices {
+	var selected Devices
+
+	for _, d := range devices {
+		if fn(d) {
+			selected = append(selected, d)
+		}
+	}
+
+	return selected
+}
+
+// BatchCallOptions configures a fleet-wide function call.
+type BatchCallOptions struct {
+	BatchOptions
+	// Timeout limits the time of every single call, if set.
+	Timeout time.Duration
+	// CanaryPercent is the percentage of the devices, at least one, which are called first. The remaining devices
+	// are only called if the failure rate of the canaries doesn't exceed MaxErrorRate.
+	CanaryPercent float64
+	// MaxErrorRate is the fraction of failed calls, from 0 to 1, above which no further calls are started. It's
+	// evaluated after every call, once MinCalls calls finished, so single early failures don't abort the rollout.
+	// Without it the rollout is only stopped by failing canaries.
+	MaxErrorRate float64
+	// MinCalls is the number of finished calls, not counting offline devices, before MaxErrorRate is evaluated,
+	// defaults to 10. The canaries are evaluated once all of them finished, regardless of MinCalls.
+	MinCalls int
+}
+
+// defaultMinCalls is the number of calls before the error rate of a batch is evaluated, if no other number was set.
+const defaultMinCalls = 10
+
+// minCalls returns the number of calls before the error rate is evaluated.
+func (opts BatchCallOptions) minCalls() int {
+	if opts.MinCalls > 0 {
+		return opts.MinCalls
+	}
+
+	return defaultMinCalls
+}
+
+// CallStatus is the outcome of calling a function on a single device of a batch.
+type CallStatus int
+
+// The outcomes of a single call. Offline devices don't count as failures.
+const (
+	CallSucceeded CallStatus = iota
+	CallFailed
+	CallOffline
+	// CallAborted devices weren't called, because the batch was aborted.
+	CallAborted
+)
+
+// DeviceCallResult is the result of calling a function on a single device.
+type DeviceCallResult struct {
+	DeviceID string
+	Status   CallStatus
+	Response FunctionResponse
+	// Err is set if the call failed or the device was offline.
+	Err error
+}
+
+// BatchCallReport is the result of BatchCallFunction.
+type BatchCallReport struct {
+	// Results has one entry per device in the order of the devices.
+	Results []DeviceCallResult
+	// Aborted is true if the batch was aborted, because the failure rate exceeded BatchCallOptions.MaxErrorRate.
+	Aborted bool
+}
+
+// Filter returns the results with the given status.
+func (r BatchCallReport) Filter(status CallStatus) []DeviceCallResult {
+	var results []DeviceCallResult
+
+	for _, result := range r.Results {
+		if result.Status == status {
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// ReturnValues maps the IDs of the devices which were called successfully to the value their function returned.
+func (r BatchCallReport) ReturnValues() map[string]int {
+	values := map[string]int{}
+
+	for _, result := range r.Filter(CallSucceeded) {
+		values[result.DeviceID] = result.Response.ReturnValue
+	}
+
+	return values
+}
+
+// BatchCallFunction calls the function with the given name and argument on all devices using a bounded number of
+// concurrent requests. If CanaryPercent is set, then the canaries are called first and the rollout is aborted if too
+// many of them fail. Errors are collected per device instead of aborting the whole batch, unless MaxErrorRate is
+// exceeded. Offline devices are reported as such and don't count as failures, see Device.CallFunction for how they're
+// detected.
+func (devices Devices) BatchCallFunction(name, argument string, opts BatchCallOptions) BatchCallReport {
+	report := BatchCallReport{Results: make([]DeviceCallResult, len(devices))}
+	var mu sync.Mutex
+	var pending []int
+	called, failed := 0, 0
+
+	for idx, d := range devices {
+		report.Results[idx] = DeviceCallResult{DeviceID: d.ID, Status: CallAborted}
+
+		if opts.SkipOffline && !d.Connected {
+			report.Results[idx].Status = CallOffline
+			report.Results[idx].Err = ErrDeviceOffline
+			continue
+		}
+
+		pending = append(pending, idx)
+	}
+
+	// exceeded reports whether the failure rate is above the limit, the caller has to hold mu.
+	exceeded := func(limit float64) bool {
+		return called > 0 && float64(failed)/float64(called) > limit
+	}
+
+	call := func(indexes []int) {
+		runWorkers(len(indexes), opts.workers(), func(i int) {
+			idx := indexes[i]
+
+			mu.Lock()
+			abort := report.Aborted
+			mu.Unlock()
+
+			if abort {
+				return
+			}
+
+			d := devices[idx]
+
+			if opts.Timeout > 0 {
+				d.client = d.client.withTimeout(opts.Timeout)
+			}
+
+			resp, err := d.CallFunction(name, argument)
+			result := DeviceCallResult{DeviceID: d.ID, Response: resp, Err: err}
+
+			switch {
+			case err == nil:
+				result.Status = CallSucceeded
+			case errors.Is(err, ErrDeviceOffline):
+				result.Status = CallOffline
+			default:
+				result.Status = CallFailed
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Results[idx] = result
+
+			if result.Status != CallOffline {
+				called++
+			}
+
+			if result.Status == CallFailed {
+				failed++
+			}
+
+			if opts.MaxErrorRate > 0 && called >= opts.minCalls() && exceeded(opts.MaxErrorRate) {
+				report.Aborted = true
+			}
+		})
+	}
+
+	if opts.CanaryPercent > 0 && len(pending) > 0 {
+		canaries := int(math.Ceil(float64(len(pending)) * opts.CanaryPercent / 100))
+
+		if canaries > len(pending) {
+			canaries = len(pending)
+		}
+
+		call(pending[:canaries])
+		pending = pending[canaries:]
+
+		// Without an error rate any failing canary stops the rollout.
+		if exceeded(opts.MaxErrorRate) {
+			report.Aborted = true
+		}
+	}
+
+	if !report.Aborted {
+		call(pending)
+	}
+
+	return report
+}
@@ -0,0 +1,248 @@
+package particle
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// generateTestFleet generates the given number of connected devices for testing.
+func generateTestFleet(n int) Devices {
+	devices := make(Devices, n)
+
+	for idx := range devices {
+		devices[idx] = generateTestDevice(string(rune('a'+idx)), "photon", 6)
+		devices[idx].Connected = true
+	}
+
+	return devices
+}
+
+func TestDevices_BatchReadVariables(t *testing.T) {
+	setup()
+	defer teardown()
+
+	devices := generateTestFleet(6)
+	devices[5].Connected = false
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+
+	for _, d := range devices {
+		d := d
+
+		mux.HandleFunc(deviceURL+"/"+d.ID+"/temperature", func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+
+			io.WriteString(w, "21."+d.ID)
+		})
+
+		if d.ID != "b" {
+			mux.HandleFunc(deviceURL+"/"+d.ID+"/state", func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, "idle")
+			})
+		}
+	}
+
+	result := devices.BatchReadVariables([]string{"temperature", "state"}, BatchOptions{Workers: 2, SkipOffline: true})
+
+	if len(result) != len(devices) {
+		t.Fatalf("Got %v results, expected %v", len(result), len(devices))
+	}
+
+	for idx, vars := range result[:5] {
+		if vars.DeviceID != devices[idx].ID || vars.Values["temperature"] != "21."+vars.DeviceID {
+			t.Errorf("Result %v = %v, expected the temperature of device %v", idx, vars, devices[idx].ID)
+		}
+	}
+
+	if !result[5].Skipped || len(result[5].Values) != 0 {
+		t.Errorf("Offline device result = %v, expected it to be skipped", result[5])
+	}
+
+	failed := result.Failed()
+
+	if len(failed) != 1 || failed[0].DeviceID != "b" || failed[0].Errors["state"] == nil {
+		t.Errorf("Failed = %v, expected the state of device b to fail", failed)
+	}
+
+	if maxRunning > 2 {
+		t.Errorf("%v requests ran concurrently, expected at most 2", maxRunning)
+	}
+}
+
+func TestDevices_BatchCallFunction(t *testing.T) {
+	setup()
+	defer teardown()
+
+	devices := generateTestFleet(6)
+	devices[5].Connected = false
+
+	for idx := range devices {
+		d := &devices[idx]
+		d.Functions = []string{"configure"}
+
+		mux.HandleFunc(deviceURL+"/"+d.ID+"/configure", func(w http.ResponseWriter, r *http.Request) {
+			switch d.ID {
+			case "b":
+				http.Error(w, `{"ok": false, "error": "Device is offline"}`, http.StatusBadRequest)
+			case "c":
+				time.Sleep(100 * time.Millisecond)
+			case "d":
+				http.Error(w, `{"ok": false, "error": "Permission denied"}`, http.StatusForbidden)
+			}
+
+			fmt.Fprintf(w, `{"id": "%v", "connected": true, "return_value": %v}`, d.ID, len(r.PostFormValue("arg")))
+		})
+	}
+
+	opts := BatchCallOptions{BatchOptions: BatchOptions{SkipOffline: true}, Timeout: 20 * time.Millisecond}
+	report := devices.BatchCallFunction("configure", "interval=60", opts)
+
+	expected := []CallStatus{CallSucceeded, CallOffline, CallFailed, CallFailed, CallSucceeded, CallOffline}
+
+	for idx, result := range report.Results {
+		if result.DeviceID != devices[idx].ID || result.Status != expected[idx] {
+			t.Errorf("Result %v = %v, expected status %v", idx, result, expected[idx])
+		}
+	}
+
+	if err := report.Results[2].Err; !errors.Is(err, ErrFunctionTimeout) {
+		t.Errorf("Slow device returned %v, expected %v", err, ErrFunctionTimeout)
+	}
+
+	if values := report.ReturnValues(); !reflect.DeepEqual(values, map[string]int{"a": 11, "e": 11}) {
+		t.Errorf("ReturnValues() = %v, expected a and e to return 11", values)
+	}
+
+	if report.Aborted || len(report.Filter(CallFailed)) != 2 {
+		t.Errorf("Report = %v, expected two failures without aborting", report)
+	}
+}
+
+func TestDevices_BatchCallFunctionAbort(t *testing.T) {
+	setup()
+	defer teardown()
+
+	devices := generateTestFleet(20)
+	var mu sync.Mutex
+	calls := 0
+	// Only the first device and the second half of the fleet fail.
+	failing := func(id string) bool {
+		return id == "a" || id >= "k"
+	}
+
+	for idx := range devices {
+		d := &devices[idx]
+		d.Functions = []string{"configure"}
+
+		mux.HandleFunc(deviceURL+"/"+d.ID+"/configure", func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			calls++
+			mu.Unlock()
+
+			if failing(d.ID) {
+				http.Error(w, `{"ok": false, "error": "Permission denied"}`, http.StatusForbidden)
+				return
+			}
+
+			fmt.Fprintf(w, `{"id": "%v", "connected": true, "return_value": 1}`, d.ID)
+		})
+	}
+
+	workers := BatchOptions{Workers: 1}
+
+	tests := []struct {
+		opts    BatchCallOptions
+		calls   int
+		aborted bool
+	}{
+		// The failing canary a stops the rollout.
+		{BatchCallOptions{CanaryPercent: 5}, 1, true},
+		// The early failure of a isn't enough, the rate exceeds 0.5 after 19 calls with 10 failures.
+		{BatchCallOptions{BatchOptions: workers, MaxErrorRate: 0.5}, 19, true},
+		{BatchCallOptions{BatchOptions: workers, MaxErrorRate: 0.5, MinCalls: 1}, 1, true},
+		{BatchCallOptions{BatchOptions: workers, MaxErrorRate: 0.6}, 20, false},
+	}
+
+	for _, test := range tests {
+		calls = 0
+		report := devices.BatchCallFunction("configure", "", test.opts)
+
+		if report.Aborted != test.aborted {
+			t.Errorf("BatchCallFunction(%+v) aborted = %v, expected %v", test.opts, report.Aborted, test.aborted)
+		}
+
+		if calls != test.calls {
+			t.Errorf("BatchCallFunction(%+v) made %v calls, expected %v", test.opts, calls, test.calls)
+		}
+
+		if aborted := len(report.Filter(CallAborted)); aborted != len(devices)-test.calls {
+			t.Errorf("BatchCallFunction(%+v) aborted %v calls, expected %v", test.opts, aborted,
+				len(devices)-test.calls)
+		}
+	}
+}
+
+func TestDevices_BatchCallFunctionListed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Devices as listed by a product, without their functions. a and d were offline when listed, d came back since.
+	devices := generateTestFleet(4)
+	devices[0].Connected = false
+	devices[3].Connected = false
+
+	var mu sync.Mutex
+	refreshed := map[string]bool{}
+
+	for _, d := range devices {
+		d := d
+
+		mux.HandleFunc(deviceURL+"/"+d.ID, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			refreshed[d.ID] = true
+			mu.Unlock()
+
+			fmt.Fprintf(w, `{"id": "%v", "connected": %v, "functions": null}`, d.ID, d.ID == "d")
+		})
+
+		mux.HandleFunc(deviceURL+"/"+d.ID+"/configure", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"id": "%v", "connected": true, "return_value": 1}`, d.ID)
+		})
+	}
+
+	report := devices.BatchCallFunction("configure", "", BatchCallOptions{CanaryPercent: 25})
+
+	if report.Aborted {
+		t.Errorf("Offline canary aborted the batch: %v", report)
+	}
+
+	expected := []CallStatus{CallOffline, CallSucceeded, CallSucceeded, CallSucceeded}
+
+	for idx, result := range report.Results {
+		if result.Status != expected[idx] {
+			t.Errorf("Result %v = %v, expected status %v", idx, result, expected[idx])
+		}
+	}
+
+	if !reflect.DeepEqual(refreshed, map[string]bool{"a": true, "d": true}) {
+		t.Errorf("Refreshed devices %v, expected only the offline devices a and d", refreshed)
+	}
+}
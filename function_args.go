@@ -0,0 +1,129 @@
+package particle
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxFunctionArgumentLength is the maximum number of characters of a function argument the device firmware accepts.
+const MaxFunctionArgumentLength = 63
+
+// ErrArgumentTooLong is returned when an encoded function argument exceeds MaxFunctionArgumentLength.
+var ErrArgumentTooLong = errors.New("function argument is too long")
+
+// FunctionArgs encodes the arguments of a function call into the single string a device function receives.
+type FunctionArgs interface {
+	EncodeArgument() (string, error)
+}
+
+// StringArg passes the string as is.
+type StringArg string
+
+// EncodeArgument returns the string.
+func (a StringArg) EncodeArgument() (string, error) {
+	return string(a), nil
+}
+
+// KeyValueArgs encodes the pairs as "key=value;key=value", sorted by key.
+type KeyValueArgs map[string]string
+
+// EncodeArgument encodes the pairs. Keys and values must not contain "=" or ";".
+func (a KeyValueArgs) EncodeArgument() (string, error) {
+	keys := make([]string, 0, len(a))
+
+	for k := range a {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+
+	for idx, k := range keys {
+		v := a[k]
+
+		if strings.ContainsAny(k, "=;") || strings.ContainsAny(v, "=;") {
+			return "", fmt.Errorf("key value pair %v=%v contains a separator", k, v)
+		}
+
+		pairs[idx] = k + "=" + v
+	}
+
+	return strings.Join(pairs, ";"), nil
+}
+
+// CSVArgs encodes the values as a single comma separated line, quoting values where needed.
+type CSVArgs []string
+
+// EncodeArgument encodes the values.
+func (a CSVArgs) EncodeArgument() (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(a); err != nil {
+		return "", err
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// JSONArgs encodes the value it wraps as JSON.
+type JSONArgs struct {
+	Value interface{}
+}
+
+// EncodeArgument encodes the value.
+func (a JSONArgs) EncodeArgument() (string, error) {
+	data, err := json.Marshal(a.Value)
+
+	return string(data), err
+}
+
+// BinaryArgs encodes raw bytes as unpadded standard base64, the most compact way to pass binary data, e.g. packed
+// structs. 47 bytes fit into a single argument.
+type BinaryArgs []byte
+
+// EncodeArgument encodes the bytes.
+func (a BinaryArgs) EncodeArgument() (string, error) {
+	return base64.RawStdEncoding.EncodeToString(a), nil
+}
+
+// EncodeFunctionArgument encodes the arguments and checks that the result doesn't exceed
+// MaxFunctionArgumentLength.
+func EncodeFunctionArgument(args FunctionArgs) (string, error) {
+	arg, err := args.EncodeArgument()
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(arg) > MaxFunctionArgumentLength {
+		return "", fmt.Errorf("%v characters, at most %v are allowed: %w", len(arg), MaxFunctionArgumentLength,
+			ErrArgumentTooLong)
+	}
+
+	return arg, nil
+}
+
+// CallFunctionWith encodes the arguments and calls the function with them. Arguments which are too long are rejected
+// before making the call.
+func (d *Device) CallFunctionWith(name string, args FunctionArgs) (FunctionResponse, error) {
+	arg, err := EncodeFunctionArgument(args)
+
+	if err != nil {
+		return FunctionResponse{}, fmt.Errorf("calling %v on device %v: %w", name, d.ID, err)
+	}
+
+	return d.CallFunction(name, arg)
+}
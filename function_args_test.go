@@ -0,0 +1,78 @@
+package particle
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFunctionArgs_EncodeArgument(t *testing.T) {
+	tests := []struct {
+		args     FunctionArgs
+		expected string
+	}{
+		{StringArg("coffee"), "coffee"},
+		{KeyValueArgs{"size": "large", "milk": "oat"}, "milk=oat;size=large"},
+		{CSVArgs{"espresso", "2", "with, sugar"}, `espresso,2,"with, sugar"`},
+		{JSONArgs{map[string]int{"shots": 2}}, `{"shots":2}`},
+		{BinaryArgs{0x01, 0x02, 0xff}, "AQL/"},
+	}
+
+	for _, test := range tests {
+		arg, err := EncodeFunctionArgument(test.args)
+
+		if err != nil {
+			t.Errorf("EncodeFunctionArgument(%v): %v", test.args, err)
+		}
+
+		if arg != test.expected {
+			t.Errorf("EncodeFunctionArgument(%v) = %v, expected %v", test.args, arg, test.expected)
+		}
+	}
+
+	if _, err := EncodeFunctionArgument(KeyValueArgs{"size": "a;b"}); err == nil {
+		t.Error("EncodeFunctionArgument() accepted a value containing a separator")
+	}
+
+	_, err := EncodeFunctionArgument(StringArg(strings.Repeat("x", MaxFunctionArgumentLength+1)))
+
+	if !errors.Is(err, ErrArgumentTooLong) {
+		t.Errorf("EncodeFunctionArgument() returned %v, expected %v", err, ErrArgumentTooLong)
+	}
+}
+
+func TestDevice_CallFunctionWith(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "photon", 10)
+	device.Functions = []string{"brew"}
+	calls := 0
+
+	mux.HandleFunc(deviceURL+"/1/brew", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if arg, expected := r.PostFormValue("arg"), "milk=oat;size=large"; arg != expected {
+			t.Errorf("Post form value = %v, expected %v", arg, expected)
+		}
+
+		w.Write([]byte(`{"id": "1", "connected": true, "return_value": 1}`))
+	})
+
+	resp, err := device.CallFunctionWith("brew", KeyValueArgs{"size": "large", "milk": "oat"})
+
+	if err != nil || resp.ReturnValue != 1 {
+		t.Errorf("CallFunctionWith() = %v, %v, expected a return value of 1", resp, err)
+	}
+
+	_, err = device.CallFunctionWith("brew", BinaryArgs(make([]byte, 48)))
+
+	if !errors.Is(err, ErrArgumentTooLong) {
+		t.Errorf("CallFunctionWith() returned %v, expected %v", err, ErrArgumentTooLong)
+	}
+
+	if calls != 1 {
+		t.Errorf("Function was called %v times, expected once", calls)
+	}
+}
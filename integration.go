@@ -0,0 +1,167 @@
+package particle
+
+import (
+	"time"
+)
+
+const integrationURL = "/v1/integrations"
+
+// IntegrationTypeWebhook is the integration type of webhooks.
+const IntegrationTypeWebhook = "Webhook"
+
+// BasicAuth holds the credentials an integration uses for HTTP basic authentication.
+type BasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Integration is the configuration of a webhook integration. The JSON field may either be a template string or any
+// value, which will be encoded as JSON. RejectUnauthorized is only sent if set, so by default the cloud checks the TLS
+// certificate of the URL.
+type Integration struct {
+	ID                 string            `json:"id,omitempty"`
+	IntegrationType    string            `json:"integration_type"`
+	Event              string            `json:"event"`
+	URL                string            `json:"url"`
+	RequestType        string            `json:"requestType,omitempty"`
+	DeviceID           string            `json:"deviceID,omitempty"`
+	Headers            map[string]string `json:"headers,omitempty"`
+	Query              map[string]string `json:"query,omitempty"`
+	JSON               interface{}       `json:"json,omitempty"`
+	Form               map[string]string `json:"form,omitempty"`
+	Body               string            `json:"body,omitempty"`
+	Auth               *BasicAuth        `json:"auth,omitempty"`
+	ResponseTemplate   string            `json:"responseTemplate,omitempty"`
+	ResponseTopic      string            `json:"responseTopic,omitempty"`
+	ErrorResponseTopic string            `json:"errorResponseTopic,omitempty"`
+	NoDefaults         bool              `json:"noDefaults,omitempty"`
+	RejectUnauthorized *bool             `json:"rejectUnauthorized,omitempty"`
+	CreatedAt          *time.Time        `json:"created_at,omitempty"`
+	client             *Client
+	baseURL            string
+}
+
+// Integrations is an array of the Integration type.
+type Integrations []Integration
+
+// IntegrationTestResult is the result of sending a test event to an integration.
+type IntegrationTestResult struct {
+	Pass       bool
+	StatusCode int `json:"statusCode"`
+	Error      string
+}
+
+// listIntegrations lists the integrations at the given end point.
+func (c *Client) listIntegrations(endPoint string) (Integrations, error) {
+	var integrations Integrations
+	_, err := c.get(endPoint, &integrations)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range integrations {
+		integrations[idx].client = c
+		integrations[idx].baseURL = endPoint
+	}
+
+	return integrations, err
+}
+
+// getIntegration gets the integration with the given id relative to the given end point.
+func (c *Client) getIntegration(endPoint, id string) (Integration, error) {
+	var integration Integration
+	_, err := c.get(endPoint+"/"+id, &integration)
+
+	if err != nil {
+		return integration, err
+	}
+
+	integration.client = c
+	integration.baseURL = endPoint
+
+	return integration, err
+}
+
+// createIntegration creates the given integration at the given end point. Integrations without a type are created as
+// webhooks.
+func (c *Client) createIntegration(endPoint string, i Integration) (Integration, error) {
+	if i.IntegrationType == "" {
+		i.IntegrationType = IntegrationTypeWebhook
+	}
+
+	var integration Integration
+	_, err := c.postJSON(endPoint, i, &integration)
+
+	if err != nil {
+		return integration, err
+	}
+
+	integration.client = c
+	integration.baseURL = endPoint
+
+	return integration, err
+}
+
+// ListIntegrations lists the integrations of the user.
+func (c *Client) ListIntegrations() (Integrations, error) {
+	return c.listIntegrations(integrationURL)
+}
+
+// GetIntegration gets a single integration of the user by it's id.
+func (c *Client) GetIntegration(id string) (Integration, error) {
+	return c.getIntegration(integrationURL, id)
+}
+
+// CreateIntegration creates a new integration for the user and returns the created integration.
+func (c *Client) CreateIntegration(i Integration) (Integration, error) {
+	return c.createIntegration(integrationURL, i)
+}
+
+// integrationsURL returns the end point of the products integrations.
+func (p *Product) integrationsURL() string {
+	return p.endPoint() + "/integrations"
+}
+
+// ListIntegrations lists the integrations of the product.
+func (p *Product) ListIntegrations() (Integrations, error) {
+	return p.client.listIntegrations(p.integrationsURL())
+}
+
+// GetIntegration gets a single integration of the product by it's id.
+func (p *Product) GetIntegration(id string) (Integration, error) {
+	return p.client.getIntegration(p.integrationsURL(), id)
+}
+
+// CreateIntegration creates a new integration for the product and returns the created integration.
+func (p *Product) CreateIntegration(i Integration) (Integration, error) {
+	return p.client.createIntegration(p.integrationsURL(), i)
+}
+
+// endPoint returns the API end point of the integration.
+func (i *Integration) endPoint() string {
+	return i.baseURL + "/" + i.ID
+}
+
+// Update saves the current configuration of the integration to the cloud.
+func (i *Integration) Update() error {
+	_, err := i.client.putJSON(i.endPoint(), i, i)
+
+	return err
+}
+
+// Test sends a test event to the integration and returns whether the target server accepted it.
+func (i *Integration) Test() (IntegrationTestResult, error) {
+	var result IntegrationTestResult
+	_, err := i.client.post(i.endPoint()+"/test", nil, &result)
+
+	return result, err
+}
+
+// Delete deletes the integration.
+func (i *Integration) Delete() error {
+	var resp okResponse
+	_, err := i.client.delete(i.endPoint(), &resp)
+
+	return err
+}
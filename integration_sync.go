@@ -0,0 +1,292 @@
+package particle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/ghodss/yaml"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// IntegrationManager is implemented by the scopes integrations can be managed in, i.e. *Client for the users own
+// integrations and *Product for the integrations of a product.
+type IntegrationManager interface {
+	ListIntegrations() (Integrations, error)
+	CreateIntegration(i Integration) (Integration, error)
+}
+
+// IntegrationConfig describes the desired integrations of a user or, if Product is set, of a product.
+type IntegrationConfig struct {
+	// Product is the id or slug of the product. If empty, the integrations belong to the users account.
+	Product      string       `json:"product,omitempty"`
+	Integrations Integrations `json:"integrations"`
+}
+
+// IntegrationAction is the kind of change a plan applies to an integration.
+type IntegrationAction string
+
+// The actions an IntegrationChange can have.
+const (
+	IntegrationCreate IntegrationAction = "create"
+	IntegrationUpdate IntegrationAction = "update"
+	IntegrationDelete IntegrationAction = "delete"
+)
+
+// IntegrationChange is a single change of an IntegrationPlan. Current is nil for creates and Desired is nil for
+// deletes.
+type IntegrationChange struct {
+	Action  IntegrationAction
+	Current *Integration
+	Desired *Integration
+	// Fields lists the JSON names of the fields an update changes.
+	Fields []string
+}
+
+// IntegrationPlan is the list of changes needed to get from the existing to the desired integrations.
+type IntegrationPlan []IntegrationChange
+
+// LoadIntegrationConfig decodes a YAML or JSON integration config from the given reader. YAML is converted to JSON
+// first, so both formats use the same field names.
+func LoadIntegrationConfig(r io.Reader) (IntegrationConfig, error) {
+	var config IntegrationConfig
+	data, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		return config, err
+	}
+
+	data, err = yaml.YAMLToJSON(data)
+
+	if err != nil {
+		return config, err
+	}
+
+	err = json.Unmarshal(data, &config)
+
+	if err != nil {
+		return config, err
+	}
+
+	for idx, i := range config.Integrations {
+		if i.Event == "" || i.URL == "" {
+			return config, fmt.Errorf("integration %d needs an event and an url", idx)
+		}
+	}
+
+	return config, nil
+}
+
+// Manager returns the IntegrationManager the config refers to, which is either the client itself or the configured
+// product.
+func (config IntegrationConfig) Manager(c *Client) (IntegrationManager, error) {
+	if config.Product == "" {
+		return c, nil
+	}
+
+	product, err := c.GetProduct(config.Product)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// integrationKey identifies which existing integration a desired one refers to. Integrations with an explicit id are
+// matched by it, otherwise by their event, url and device filter.
+func integrationKey(i Integration) string {
+	if i.ID != "" {
+		return "id:" + i.ID
+	}
+
+	return targetKey(i)
+}
+
+// targetKey identifies an integration by the events it receives and the url it sends them to.
+func targetKey(i Integration) string {
+	return strings.Join([]string{i.Event, i.URL, i.DeviceID}, "|")
+}
+
+// integrationFields returns the configuration of the integration as generic JSON map, without the fields the cloud
+// manages itself.
+func integrationFields(i Integration) map[string]interface{} {
+	i.ID = ""
+	i.CreatedAt = nil
+
+	if i.IntegrationType == "" {
+		i.IntegrationType = IntegrationTypeWebhook
+	}
+
+	fields := map[string]interface{}{}
+	data, _ := json.Marshal(i)
+	json.Unmarshal(data, &fields)
+
+	return fields
+}
+
+// changedIntegrationFields returns the sorted JSON names of the fields the desired integration sets to a different
+// value than the current one. Fields the desired integration leaves out are filled in by the cloud, so they aren't
+// compared.
+func changedIntegrationFields(current, desired Integration) []string {
+	a, b := integrationFields(current), integrationFields(desired)
+	var changed []string
+
+	for key, value := range b {
+		if !reflect.DeepEqual(value, a[key]) {
+			changed = append(changed, key)
+		}
+	}
+
+	sort.Strings(changed)
+
+	return changed
+}
+
+// mergeIntegration returns the current integration with the fields the desired one sets replaced, so an update keeps
+// the values of the fields the desired integration leaves out.
+func mergeIntegration(current, desired Integration) (Integration, error) {
+	fields := integrationFields(current)
+
+	for key, value := range integrationFields(desired) {
+		fields[key] = value
+	}
+
+	var merged Integration
+	data, err := json.Marshal(fields)
+
+	if err != nil {
+		return merged, err
+	}
+
+	err = json.Unmarshal(data, &merged)
+	merged.ID = current.ID
+	merged.client = current.client
+	merged.baseURL = current.baseURL
+
+	return merged, err
+}
+
+// PlanIntegrations computes the changes needed to turn the current integrations into the desired ones. Only the fields
+// a desired integration sets are compared and updated, the others keep their current value. Webhooks which exist but
+// aren't desired get deleted, integrations of other types are left alone.
+func PlanIntegrations(current, desired Integrations) IntegrationPlan {
+	var plan IntegrationPlan
+	var webhooks Integrations
+
+	for _, i := range current {
+		if i.IntegrationType == IntegrationTypeWebhook {
+			webhooks = append(webhooks, i)
+		}
+	}
+
+	current = webhooks
+	existing := make(map[string]*Integration, len(current))
+	matched := make(map[*Integration]bool, len(current))
+
+	for idx := range current {
+		i := &current[idx]
+		existing["id:"+i.ID] = i
+
+		if key := targetKey(*i); existing[key] == nil {
+			existing[key] = i
+		}
+	}
+
+	for idx := range desired {
+		want := &desired[idx]
+		have := existing[integrationKey(*want)]
+
+		if have == nil || matched[have] {
+			plan = append(plan, IntegrationChange{Action: IntegrationCreate, Desired: want})
+			continue
+		}
+
+		matched[have] = true
+
+		if fields := changedIntegrationFields(*have, *want); len(fields) > 0 {
+			plan = append(plan, IntegrationChange{Action: IntegrationUpdate, Current: have, Desired: want, Fields: fields})
+		}
+	}
+
+	for idx := range current {
+		if have := &current[idx]; !matched[have] {
+			plan = append(plan, IntegrationChange{Action: IntegrationDelete, Current: have})
+		}
+	}
+
+	return plan
+}
+
+// PlanIntegrationSync fetches the existing integrations of the manager and plans the changes towards the desired
+// ones.
+func PlanIntegrationSync(m IntegrationManager, desired Integrations) (IntegrationPlan, error) {
+	current, err := m.ListIntegrations()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return PlanIntegrations(current, desired), nil
+}
+
+// String returns a single line describing the change.
+func (change IntegrationChange) String() string {
+	switch change.Action {
+	case IntegrationCreate:
+		return fmt.Sprintf("+ create %v -> %v", change.Desired.Event, change.Desired.URL)
+	case IntegrationUpdate:
+		return fmt.Sprintf("~ update %v %v -> %v (%v)", change.Current.ID, change.Desired.Event, change.Desired.URL,
+			strings.Join(change.Fields, ", "))
+	case IntegrationDelete:
+		return fmt.Sprintf("- delete %v %v -> %v", change.Current.ID, change.Current.Event, change.Current.URL)
+	}
+
+	return fmt.Sprintf("? %v", change.Action)
+}
+
+// String returns the plan in a human readable form, one change per line.
+func (plan IntegrationPlan) String() string {
+	if len(plan) == 0 {
+		return "No changes, integrations are up to date.\n"
+	}
+
+	var buf bytes.Buffer
+
+	for _, change := range plan {
+		buf.WriteString(change.String())
+		buf.WriteByte('\n')
+	}
+
+	return buf.String()
+}
+
+// Apply executes the changes of the plan using the given manager. Apply stops at the first change which fails.
+func (plan IntegrationPlan) Apply(m IntegrationManager) error {
+	for _, change := range plan {
+		var err error
+
+		switch change.Action {
+		case IntegrationCreate:
+			_, err = m.CreateIntegration(*change.Desired)
+		case IntegrationUpdate:
+			var i Integration
+			i, err = mergeIntegration(*change.Current, *change.Desired)
+
+			if err == nil {
+				err = i.Update()
+			}
+		case IntegrationDelete:
+			err = change.Current.Delete()
+		}
+
+		if err != nil {
+			return fmt.Errorf("%v: %v", change, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package particle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadIntegrationConfig(t *testing.T) {
+	config, err := LoadIntegrationConfig(strings.NewReader(`{"product": "lamp-v100", "integrations": [
+		{"event": "temperature", "url": "https://example.com/temp", "requestType": "POST",
+		 "json": {"value": "{{PARTICLE_EVENT_VALUE}}"}}]}`))
+
+	if err != nil {
+		t.Fatalf("LoadIntegrationConfig(): %v", err)
+	}
+
+	if config.Product != "lamp-v100" || len(config.Integrations) != 1 {
+		t.Fatalf("Config = %v, expected product lamp-v100 with one integration", config)
+	}
+
+	if j := config.Integrations[0].JSON; !reflect.DeepEqual(j, map[string]interface{}{"value": "{{PARTICLE_EVENT_VALUE}}"}) {
+		t.Errorf("JSON template = %v, expected the value template", j)
+	}
+
+	config, err = LoadIntegrationConfig(strings.NewReader(`product: lamp-v100
+integrations:
+  - event: temperature
+    url: https://example.com/temp
+    headers:
+      X-Secret: s3cr3t
+`))
+
+	if err != nil {
+		t.Fatalf("LoadIntegrationConfig() with YAML: %v", err)
+	}
+
+	if len(config.Integrations) != 1 || config.Integrations[0].Headers["X-Secret"] != "s3cr3t" {
+		t.Errorf("YAML config = %v, expected one integration with the secret header", config)
+	}
+
+	_, err = LoadIntegrationConfig(strings.NewReader(`{"integrations": [{"event": "temperature"}]}`))
+
+	if err == nil {
+		t.Errorf("LoadIntegrationConfig() accepted an integration without url")
+	}
+}
+
+func TestPlanIntegrations(t *testing.T) {
+	current := Integrations{
+		generateTestIntegration("1", "temperature"),
+		generateTestIntegration("2", "state"),
+		generateTestIntegration("3", "obsolete"),
+	}
+
+	unchanged := generateTestIntegration("", "temperature")
+	changed := generateTestIntegration("", "state")
+	changed.Headers = map[string]string{"X-Secret": "rotated"}
+	added := generateTestIntegration("", "humidity")
+
+	plan := PlanIntegrations(current, Integrations{unchanged, changed, added})
+
+	if len(plan) != 3 {
+		t.Fatalf("Plan has %d changes, expected 3:\n%v", len(plan), plan)
+	}
+
+	if c := plan[0]; c.Action != IntegrationUpdate || c.Current.ID != "2" || !reflect.DeepEqual(c.Fields, []string{"headers"}) {
+		t.Errorf("First change = %v, expected an update of the headers of integration 2", c)
+	}
+
+	if c := plan[1]; c.Action != IntegrationCreate || c.Desired.Event != "humidity" {
+		t.Errorf("Second change = %v, expected the humidity integration to be created", c)
+	}
+
+	if c := plan[2]; c.Action != IntegrationDelete || c.Current.ID != "3" {
+		t.Errorf("Third change = %v, expected integration 3 to be deleted", c)
+	}
+
+	if p := PlanIntegrations(current[:1], Integrations{unchanged}); len(p) != 0 {
+		t.Errorf("Plan for unchanged integrations = %v, expected no changes", p)
+	}
+}
+
+func TestPlanIntegrations_CloudDefaults(t *testing.T) {
+	reject := true
+	current := generateTestIntegration("1", "temperature")
+	current.RejectUnauthorized = &reject
+	current.NoDefaults = true
+
+	desired := Integration{Event: "temperature", URL: current.URL, Headers: current.Headers}
+
+	if p := PlanIntegrations(Integrations{current}, Integrations{desired}); len(p) != 0 {
+		t.Errorf("Plan for integration with cloud defaults = %v, expected no changes", p)
+	}
+}
+
+func TestIntegrationPlan_Apply(t *testing.T) {
+	setup()
+	defer teardown()
+
+	current := Integrations{generateTestIntegration("1", "temperature"), generateTestIntegration("2", "obsolete")}
+	pubsub := generateTestIntegration("4", "telemetry")
+	pubsub.IntegrationType = "GoogleCloudPubSub"
+	current = append(current, pubsub)
+	changed := Integration{Event: "temperature", URL: current[0].URL, RequestType: "PUT"}
+	added := generateTestIntegration("", "humidity")
+
+	var calls []string
+
+	mux.HandleFunc(integrationURL, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			json.NewEncoder(w).Encode(current)
+		case "POST":
+			calls = append(calls, "create")
+			fmt.Fprint(w, `{"id": "3"}`)
+		default:
+			t.Errorf("Unexpected request method %v", r.Method)
+		}
+	})
+
+	mux.HandleFunc(integrationURL+"/1", func(w http.ResponseWriter, r *http.Request) {
+		if m := "PUT"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		var body Integration
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Could not decode integration: %v", err)
+		}
+
+		if body.RequestType != "PUT" || !reflect.DeepEqual(body.Headers, current[0].Headers) {
+			t.Errorf("Updated integration = %v, expected the new request type and the current headers", body)
+		}
+
+		calls = append(calls, "update")
+		fmt.Fprint(w, `{"id": "1"}`)
+	})
+
+	mux.HandleFunc(integrationURL+"/4", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Integration of type %v was changed with %v", pubsub.IntegrationType, r.Method)
+	})
+
+	mux.HandleFunc(integrationURL+"/2", func(w http.ResponseWriter, r *http.Request) {
+		if m := "DELETE"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		calls = append(calls, "delete")
+		fmt.Fprint(w, `{"ok": true}`)
+	})
+
+	plan, err := PlanIntegrationSync(client, Integrations{changed, added})
+
+	if err != nil {
+		t.Fatalf("PlanIntegrationSync(): %v", err)
+	}
+
+	if err := plan.Apply(client); err != nil {
+		t.Fatalf("Apply(): %v", err)
+	}
+
+	if expected := []string{"update", "create", "delete"}; !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Applied %v, expected %v", calls, expected)
+	}
+}
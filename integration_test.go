@@ -0,0 +1,211 @@
+package particle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// generateTestIntegration generates a webhook integration of the user for testing.
+func generateTestIntegration(id, event string) Integration {
+	integration := Integration{
+		ID:              id,
+		IntegrationType: IntegrationTypeWebhook,
+		Event:           event,
+		URL:             "https://example.com/" + event,
+		RequestType:     "POST",
+		Headers:         map[string]string{"X-Secret": "s3cr3t"},
+		Form:            map[string]string{"value": "{{PARTICLE_EVENT_VALUE}}"},
+		ResponseTopic:   "{{PARTICLE_DEVICE_ID}}/hook-response/" + event,
+		client:          client,
+		baseURL:         integrationURL,
+	}
+
+	return integration
+}
+
+func TestClient_ListIntegrations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	integrations := Integrations{generateTestIntegration("1", "temperature"), generateTestIntegration("2", "state")}
+
+	mux.HandleFunc(integrationURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		err := json.NewEncoder(w).Encode(integrations)
+
+		if err != nil {
+			t.Fatalf("Could not encode integrations: %v", err)
+		}
+	})
+
+	integrationsResp, err := client.ListIntegrations()
+
+	if err != nil {
+		t.Fatalf("ListIntegrations(): %v", err)
+	}
+
+	if !reflect.DeepEqual(integrationsResp, integrations) {
+		t.Errorf("Response integrations %v don't match with originals: %v", integrationsResp, integrations)
+	}
+}
+
+func TestProduct_CreateIntegration(t *testing.T) {
+	setup()
+	defer teardown()
+
+	product := generateTestProduct(1, "lamp")
+	integration := generateTestIntegration("", "temperature")
+	integration.IntegrationType = ""
+
+	mux.HandleFunc(product.integrationsURL(), func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		var body Integration
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Could not decode integration: %v", err)
+		}
+
+		if body.IntegrationType != IntegrationTypeWebhook {
+			t.Errorf("Integration type = %v, expected %v", body.IntegrationType, IntegrationTypeWebhook)
+		}
+
+		if !reflect.DeepEqual(body.Headers, integration.Headers) {
+			t.Errorf("Integration headers = %v, expected %v", body.Headers, integration.Headers)
+		}
+
+		body.ID = "42"
+		err := json.NewEncoder(w).Encode(body)
+
+		if err != nil {
+			t.Fatalf("Could not encode integration: %v", err)
+		}
+	})
+
+	created, err := product.CreateIntegration(integration)
+
+	if err != nil {
+		t.Fatalf("CreateIntegration(): %v", err)
+	}
+
+	if created.ID != "42" {
+		t.Errorf("Created integration has id %v, expected 42", created.ID)
+	}
+
+	if e := product.integrationsURL() + "/42"; created.endPoint() != e {
+		t.Errorf("Created integration has end point %v, expected %v", created.endPoint(), e)
+	}
+}
+
+func TestIntegration_MarshalJSON(t *testing.T) {
+	integration := Integration{Event: "e", URL: "https://example.com"}
+	data, err := json.Marshal(integration)
+
+	if err != nil {
+		t.Fatalf("Could not encode integration: %v", err)
+	}
+
+	if strings.Contains(string(data), "rejectUnauthorized") {
+		t.Errorf("Integration encoded as %s, expected rejectUnauthorized to be left to the cloud", data)
+	}
+
+	reject := false
+	integration.RejectUnauthorized = &reject
+	data, err = json.Marshal(integration)
+
+	if err != nil {
+		t.Fatalf("Could not encode integration: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"rejectUnauthorized":false`) {
+		t.Errorf("Integration encoded as %s, expected rejectUnauthorized to be false", data)
+	}
+}
+
+func TestIntegration_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	integration := generateTestIntegration("1", "temperature")
+	integration.URL = "https://example.com/v2"
+
+	mux.HandleFunc(integration.endPoint(), func(w http.ResponseWriter, r *http.Request) {
+		if m := "PUT"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		var body Integration
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Could not decode integration: %v", err)
+		}
+
+		if body.URL != integration.URL {
+			t.Errorf("Integration url = %v, expected %v", body.URL, integration.URL)
+		}
+
+		json.NewEncoder(w).Encode(body)
+	})
+
+	if err := integration.Update(); err != nil {
+		t.Fatalf("Update(): %v", err)
+	}
+}
+
+func TestIntegration_Test(t *testing.T) {
+	setup()
+	defer teardown()
+
+	integration := generateTestIntegration("1", "temperature")
+
+	mux.HandleFunc(integration.endPoint()+"/test", func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		fmt.Fprint(w, `{"pass": false, "statusCode": 500, "error": "Internal Server Error"}`)
+	})
+
+	result, err := integration.Test()
+
+	if err != nil {
+		t.Fatalf("Test(): %v", err)
+	}
+
+	expected := IntegrationTestResult{false, 500, "Internal Server Error"}
+	if result != expected {
+		t.Errorf("Test result = %v, expected %v", result, expected)
+	}
+}
+
+func TestIntegration_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	integration := generateTestIntegration("1", "temperature")
+	called := false
+
+	mux.HandleFunc(integration.endPoint(), func(w http.ResponseWriter, r *http.Request) {
+		if m := "DELETE"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		called = true
+		fmt.Fprint(w, `{"ok": true}`)
+	})
+
+	if err := integration.Delete(); err != nil {
+		t.Fatalf("Delete(): %v", err)
+	}
+
+	if !called {
+		t.Errorf("Delete() didn't call the API")
+	}
+}
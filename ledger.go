@@ -0,0 +1,170 @@
+package particle
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const ledgerURL = "/v1/ledgers"
+
+// ErrLedgerConflict is returned when a ledger instance was changed by someone else since the revision it was updated
+// from.
+var ErrLedgerConflict = errors.New("ledger instance was modified concurrently")
+
+// LedgerScope is the level a ledger keeps one instance for.
+type LedgerScope string
+
+// The scopes a ledger can have.
+const (
+	LedgerScopeOwner   LedgerScope = "Owner"
+	LedgerScopeProduct LedgerScope = "Product"
+	LedgerScopeDevice  LedgerScope = "Device"
+)
+
+// LedgerDirection defines who is able to write a ledger.
+type LedgerDirection string
+
+// The directions a ledger can have.
+const (
+	LedgerCloudOnly     LedgerDirection = "CloudOnly"
+	LedgerCloudToDevice LedgerDirection = "CloudToDevice"
+	LedgerDeviceToCloud LedgerDirection = "DeviceToCloud"
+)
+
+// Ledger is the definition of a ledger.
+type Ledger struct {
+	Name        string          `json:"name"`
+	DisplayName string          `json:"display_name,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Scope       LedgerScope     `json:"scope"`
+	Direction   LedgerDirection `json:"direction"`
+	CreatedAt   *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time      `json:"updated_at,omitempty"`
+}
+
+// Ledgers is an array of the Ledger type.
+type Ledgers []Ledger
+
+// LedgerInstance is the data a ledger holds for a single device, product or owner.
+type LedgerInstance struct {
+	Name  string
+	Scope struct {
+		Type  LedgerScope
+		Value string
+		Name  string
+	}
+	Data      json.RawMessage
+	Revision  int
+	SizeBytes int       `json:"size_bytes"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Decode decodes the JSON data of the instance into v.
+func (i LedgerInstance) Decode(v interface{}) error {
+	return json.Unmarshal(i.Data, v)
+}
+
+// ListLedgers lists the ledger definitions of the user.
+func (c *Client) ListLedgers() (Ledgers, error) {
+	var resp struct {
+		Ledgers Ledgers
+	}
+
+	_, err := c.get(ledgerURL, &resp)
+
+	return resp.Ledgers, err
+}
+
+// GetLedger gets a single ledger definition by it's name.
+func (c *Client) GetLedger(name string) (Ledger, error) {
+	var resp struct {
+		Ledger Ledger
+	}
+
+	_, err := c.get(ledgerURL+"/"+url.PathEscape(name), &resp)
+
+	return resp.Ledger, err
+}
+
+// CreateLedger creates a new ledger definition.
+func (c *Client) CreateLedger(l Ledger) (Ledger, error) {
+	var resp struct {
+		Ledger Ledger
+	}
+
+	_, err := c.postJSON(ledgerURL, struct {
+		Ledger Ledger `json:"ledger"`
+	}{l}, &resp)
+
+	return resp.Ledger, err
+}
+
+// UpdateLedger updates the definition of the ledger with the name of the given ledger.
+func (c *Client) UpdateLedger(l Ledger) (Ledger, error) {
+	var resp struct {
+		Ledger Ledger
+	}
+
+	_, err := c.putJSON(ledgerURL+"/"+url.PathEscape(l.Name), struct {
+		Ledger Ledger `json:"ledger"`
+	}{l}, &resp)
+
+	return resp.Ledger, err
+}
+
+// ledgerInstanceURL returns the end point of the ledgers instance for the given scope value.
+func ledgerInstanceURL(ledger, scopeValue string) string {
+	return ledgerURL + "/" + url.PathEscape(ledger) + "/instances/" + url.PathEscape(scopeValue)
+}
+
+// GetLedgerInstance gets the instance of the ledger for the given scope value, which is the device id, product id or
+// owner id depending on the scope of the ledger.
+func (c *Client) GetLedgerInstance(ledger, scopeValue string) (LedgerInstance, error) {
+	var resp struct {
+		Instance LedgerInstance
+	}
+
+	_, err := c.get(ledgerInstanceURL(ledger, scopeValue), &resp)
+
+	return resp.Instance, err
+}
+
+// SetLedgerInstance replaces the data of the ledgers instance for the given scope value. If revision is greater than
+// 0, the update only succeeds if the instance still has that revision, otherwise ErrLedgerConflict is returned.
+func (c *Client) SetLedgerInstance(ledger, scopeValue string, data interface{}, revision int) (LedgerInstance, error) {
+	var body struct {
+		Instance struct {
+			Data     interface{} `json:"data"`
+			Revision int         `json:"revision,omitempty"`
+		} `json:"instance"`
+	}
+
+	body.Instance.Data = data
+	body.Instance.Revision = revision
+
+	var resp struct {
+		Instance LedgerInstance
+	}
+
+	_, err := c.putJSON(ledgerInstanceURL(ledger, scopeValue), body, &resp)
+
+	if errResp, ok := err.(*ErrorResponse); ok {
+		if code := errResp.Response.StatusCode; code == http.StatusConflict || code == http.StatusPreconditionFailed {
+			return resp.Instance, ErrLedgerConflict
+		}
+	}
+
+	return resp.Instance, err
+}
+
+// DeleteLedgerInstance deletes the instance of the ledger for the given scope value.
+func (c *Client) DeleteLedgerInstance(ledger, scopeValue string) error {
+	var resp okResponse
+	_, err := c.delete(ledgerInstanceURL(ledger, scopeValue), &resp)
+
+	return err
+}
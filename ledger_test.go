@@ -0,0 +1,186 @@
+package particle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_ListLedgers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ledgers := Ledgers{
+		{Name: "config", Scope: LedgerScopeProduct, Direction: LedgerCloudToDevice},
+		{Name: "status", Scope: LedgerScopeDevice, Direction: LedgerDeviceToCloud},
+	}
+
+	mux.HandleFunc(ledgerURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		err := json.NewEncoder(w).Encode(map[string]Ledgers{"ledgers": ledgers})
+
+		if err != nil {
+			t.Fatalf("Could not encode ledgers: %v", err)
+		}
+	})
+
+	ledgersResp, err := client.ListLedgers()
+
+	if err != nil {
+		t.Fatalf("ListLedgers(): %v", err)
+	}
+
+	if !reflect.DeepEqual(ledgersResp, ledgers) {
+		t.Errorf("Response ledgers %v don't match with originals: %v", ledgersResp, ledgers)
+	}
+}
+
+func TestClient_CreateLedger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ledger := Ledger{Name: "config", Scope: LedgerScopeProduct, Direction: LedgerCloudToDevice}
+
+	mux.HandleFunc(ledgerURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		var body struct {
+			Ledger Ledger
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Could not decode ledger: %v", err)
+		}
+
+		if !reflect.DeepEqual(body.Ledger, ledger) {
+			t.Errorf("Request ledger = %v, expected %v", body.Ledger, ledger)
+		}
+
+		json.NewEncoder(w).Encode(body)
+	})
+
+	created, err := client.CreateLedger(ledger)
+
+	if err != nil {
+		t.Fatalf("CreateLedger(): %v", err)
+	}
+
+	if !reflect.DeepEqual(created, ledger) {
+		t.Errorf("Created ledger = %v, expected %v", created, ledger)
+	}
+}
+
+func TestClient_GetLedgerInstance(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(ledgerInstanceURL("config", "1"), func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		fmt.Fprint(w, `{"instance": {"name": "config", "scope": {"type": "Device", "value": "1"},
+			"data": {"interval": 60, "mode": "eco"}, "revision": 3, "size_bytes": 31}}`)
+	})
+
+	instance, err := client.GetLedgerInstance("config", "1")
+
+	if err != nil {
+		t.Fatalf("GetLedgerInstance(): %v", err)
+	}
+
+	if instance.Revision != 3 || instance.Scope.Type != LedgerScopeDevice {
+		t.Errorf("Instance = %v, expected revision 3 of a device instance", instance)
+	}
+
+	var data struct {
+		Interval int
+		Mode     string
+	}
+
+	if err := instance.Decode(&data); err != nil {
+		t.Fatalf("Decode(): %v", err)
+	}
+
+	if data.Interval != 60 || data.Mode != "eco" {
+		t.Errorf("Decoded data = %v, expected interval 60 in eco mode", data)
+	}
+}
+
+func TestClient_SetLedgerInstance(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(ledgerInstanceURL("config", "1"), func(w http.ResponseWriter, r *http.Request) {
+		if m := "PUT"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		var body struct {
+			Instance struct {
+				Data     map[string]int
+				Revision int
+			}
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Could not decode instance: %v", err)
+		}
+
+		if body.Instance.Revision != 3 {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"error": "revision mismatch"}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"instance": {"name": "config", "data": {"interval": %d}, "revision": 4}}`,
+			body.Instance.Data["interval"])
+	})
+
+	instance, err := client.SetLedgerInstance("config", "1", map[string]int{"interval": 30}, 3)
+
+	if err != nil {
+		t.Fatalf("SetLedgerInstance(): %v", err)
+	}
+
+	if instance.Revision != 4 || string(instance.Data) != `{"interval": 30}` {
+		t.Errorf("Instance = %v, expected revision 4 with interval 30", instance)
+	}
+
+	_, err = client.SetLedgerInstance("config", "1", map[string]int{"interval": 30}, 2)
+
+	if err != ErrLedgerConflict {
+		t.Errorf("SetLedgerInstance() with an outdated revision returned %v, expected %v", err, ErrLedgerConflict)
+	}
+}
+
+func TestClient_DeleteLedgerInstance(t *testing.T) {
+	setup()
+	defer teardown()
+
+	called := false
+
+	mux.HandleFunc(ledgerInstanceURL("config", "1"), func(w http.ResponseWriter, r *http.Request) {
+		if m := "DELETE"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		called = true
+		fmt.Fprint(w, `{"ok": true}`)
+	})
+
+	if err := client.DeleteLedgerInstance("config", "1"); err != nil {
+		t.Fatalf("DeleteLedgerInstance(): %v", err)
+	}
+
+	if !called {
+		t.Errorf("DeleteLedgerInstance() didn't call the API")
+	}
+}
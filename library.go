@@ -0,0 +1,425 @@
+package particle
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const libraryURL = "/v1/libraries"
+
+// libraryPropertiesFile is the name of the file which holds the metadata of a library.
+const libraryPropertiesFile = "library.properties"
+
+// Library information of a single firmware library version.
+type Library struct {
+	ID            string
+	Name          string
+	Version       string
+	Author        string
+	License       string
+	Sentence      string
+	Paragraph     string
+	URL           string
+	Repository    string
+	Architectures []string
+	Visibility    string
+	Mine          bool
+	Verified      bool
+	Installs      int
+	DownloadURL   string
+}
+
+// Libraries is an array of the Library type.
+type Libraries []Library
+
+// libraryData is a library the way the API encodes it.
+type libraryData struct {
+	ID         string
+	Attributes Library
+	Links      struct {
+		Download string
+	}
+}
+
+// library converts the API representation into a Library.
+func (data libraryData) library() Library {
+	lib := data.Attributes
+	lib.ID = data.ID
+	lib.DownloadURL = data.Links.Download
+
+	return lib
+}
+
+// LibraryListOptions filters and pages the results of ListLibraries. Zero values are left to the APIs defaults.
+type LibraryListOptions struct {
+	// Filter searches the libraries by name.
+	Filter string
+	// Scope is one of "all", "official", "public", "private" or "mine".
+	Scope string
+	// Sort is the field to sort by, prefix it with "-" to sort descending, e.g. "-installs".
+	Sort          string
+	Architectures []string
+	Page          int
+	Limit         int
+}
+
+// query encodes the options as URL query.
+func (opts LibraryListOptions) query() url.Values {
+	query := url.Values{}
+
+	if opts.Filter != "" {
+		query.Add("filter", opts.Filter)
+	}
+
+	if opts.Scope != "" {
+		query.Add("scope", opts.Scope)
+	}
+
+	if opts.Sort != "" {
+		query.Add("sort", opts.Sort)
+	}
+
+	if len(opts.Architectures) > 0 {
+		query.Add("architectures", strings.Join(opts.Architectures, ","))
+	}
+
+	if opts.Page > 0 {
+		query.Add("page", strconv.Itoa(opts.Page))
+	}
+
+	if opts.Limit > 0 {
+		query.Add("limit", strconv.Itoa(opts.Limit))
+	}
+
+	return query
+}
+
+// LibraryProperties is the metadata of a library as found in it's library.properties file.
+type LibraryProperties struct {
+	Name          string
+	Version       string
+	Author        string
+	License       string
+	Sentence      string
+	Paragraph     string
+	URL           string
+	Repository    string
+	Architectures []string
+	// Dependencies maps the names of the libraries this library depends on to their versions.
+	Dependencies map[string]string
+}
+
+// listLibraries decodes the list of libraries at the given end point.
+func (c *Client) listLibraries(endPoint string) (Libraries, error) {
+	var resp struct {
+		Data []libraryData
+	}
+
+	_, err := c.get(endPoint, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	libraries := make(Libraries, len(resp.Data))
+
+	for idx, data := range resp.Data {
+		libraries[idx] = data.library()
+	}
+
+	return libraries, nil
+}
+
+// ListLibraries lists or searches the firmware libraries.
+func (c *Client) ListLibraries(opts LibraryListOptions) (Libraries, error) {
+	endPoint := libraryURL
+
+	if query := opts.query(); len(query) > 0 {
+		endPoint += "?" + query.Encode()
+	}
+
+	return c.listLibraries(endPoint)
+}
+
+// GetLibrary gets a single library by it's name. If version is empty, then the latest version is returned.
+func (c *Client) GetLibrary(name, version string) (Library, error) {
+	endPoint := libraryURL + "/" + url.PathEscape(name)
+
+	if version != "" {
+		endPoint += "?version=" + url.QueryEscape(version)
+	}
+
+	var resp struct {
+		Data libraryData
+	}
+
+	_, err := c.get(endPoint, &resp)
+
+	return resp.Data.library(), err
+}
+
+// LibraryVersions lists all versions of the library with the given name.
+func (c *Client) LibraryVersions(name string) (Libraries, error) {
+	return c.listLibraries(libraryURL + "/" + url.PathEscape(name) + "/versions")
+}
+
+// DownloadLibrary writes the archive of the given library version to w. If version is empty, then the latest version
+// is downloaded.
+func (c *Client) DownloadLibrary(name, version string, w io.Writer) error {
+	lib, err := c.GetLibrary(name, version)
+
+	if err != nil {
+		return err
+	}
+
+	if lib.DownloadURL == "" {
+		return fmt.Errorf("library %v %v has no download link", name, lib.Version)
+	}
+
+	link, err := url.Parse(lib.DownloadURL)
+
+	if err != nil {
+		return err
+	}
+
+	var req *http.Request
+
+	// Only send the token along if the archive is hosted by the API itself.
+	if link.IsAbs() && link.Host != c.BaseURL.Host {
+		req, err = http.NewRequest("GET", link.String(), nil)
+	} else {
+		req, err = c.newRequest("GET", lib.DownloadURL, nil)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req, nil)
+
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, resp.Body)
+
+	return err
+}
+
+// UploadLibrary uploads the library in the given directory as new private version. The cloud reads the name of the
+// library from the library.properties file in the archive.
+func (c *Client) UploadLibrary(dir string) (Library, error) {
+	props, err := ReadLibraryProperties(dir)
+
+	if err != nil {
+		return Library{}, err
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("archive", props.Name+".tar.gz")
+
+	if err != nil {
+		return Library{}, err
+	}
+
+	err = writeLibraryArchive(dir, part)
+
+	if err != nil {
+		return Library{}, err
+	}
+
+	err = form.Close()
+
+	if err != nil {
+		return Library{}, err
+	}
+
+	req, err := c.newRequest("POST", libraryURL, &body)
+
+	if err != nil {
+		return Library{}, err
+	}
+
+	req.Header.Add("Content-Type", form.FormDataContentType())
+
+	var resp struct {
+		Data libraryData
+	}
+
+	_, err = c.do(req, &resp)
+
+	return resp.Data.library(), err
+}
+
+// PublishLibrary makes the latest private version of the library with the given name public.
+func (c *Client) PublishLibrary(name string) (Library, error) {
+	form := url.Values{}
+	form.Add("visibility", "public")
+
+	req, err := c.newFormRequest("PATCH", libraryURL+"/"+url.PathEscape(name), form)
+
+	if err != nil {
+		return Library{}, err
+	}
+
+	var resp struct {
+		Data libraryData
+	}
+
+	_, err = c.do(req, &resp)
+
+	return resp.Data.library(), err
+}
+
+// ReadLibraryProperties reads the library.properties file of the library in the given directory.
+func ReadLibraryProperties(dir string) (LibraryProperties, error) {
+	f, err := os.Open(filepath.Join(dir, libraryPropertiesFile))
+
+	if err != nil {
+		return LibraryProperties{}, err
+	}
+
+	defer f.Close()
+
+	return ParseLibraryProperties(f)
+}
+
+// ParseLibraryProperties parses the key=value lines of a library.properties file.
+func ParseLibraryProperties(r io.Reader) (LibraryProperties, error) {
+	props := LibraryProperties{Dependencies: map[string]string{}}
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		idx := strings.Index(line, "=")
+
+		if idx < 0 {
+			return props, fmt.Errorf("invalid line in %v: %v", libraryPropertiesFile, line)
+		}
+
+		key, value := strings.TrimSpace(line[:idx]), strings.TrimSpace(line[idx+1:])
+
+		switch key {
+		case "name":
+			props.Name = value
+		case "version":
+			props.Version = value
+		case "author":
+			props.Author = value
+		case "license":
+			props.License = value
+		case "sentence":
+			props.Sentence = value
+		case "paragraph":
+			props.Paragraph = value
+		case "url":
+			props.URL = value
+		case "repository":
+			props.Repository = value
+		case "architectures":
+			for _, arch := range strings.Split(value, ",") {
+				props.Architectures = append(props.Architectures, strings.TrimSpace(arch))
+			}
+		default:
+			if strings.HasPrefix(key, "dependencies.") {
+				props.Dependencies[strings.TrimPrefix(key, "dependencies.")] = value
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return props, err
+	}
+
+	if props.Name == "" || props.Version == "" {
+		return props, fmt.Errorf("%v needs a name and a version", libraryPropertiesFile)
+	}
+
+	return props, nil
+}
+
+// writeLibraryArchive writes the files of the given directory as gzipped tar archive to w. Hidden files and
+// directories, like .git, are skipped.
+func writeLibraryArchive(dir string, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+
+		if err != nil || rel == "." {
+			return err
+		}
+
+		if strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+
+		if err != nil {
+			return err
+		}
+
+		header.Name = filepath.ToSlash(rel)
+
+		if err := archive.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		_, err = io.Copy(archive, f)
+
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if err := archive.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
@@ -0,0 +1,236 @@
+package particle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const testLibraryProperties = `# A comment
+name=neopixel
+version=0.0.10
+author=Adafruit
+license=MIT
+sentence=An Implementation of Adafruit's NeoPixel Library for the Spark Core
+architectures=particle-photon, particle-electron
+dependencies.other=1.2.3
+`
+
+const testLibraryData = `{"id": "neopixel", "attributes": {"name": "neopixel", "version": "0.0.10", "license": "MIT",
+	"architectures": ["particle-photon"], "visibility": "public", "verified": true, "installs": 1000},
+	"links": {"download": "%v"}}`
+
+func TestClient_ListLibraries(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(libraryURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		query := r.URL.Query()
+
+		if f, p, l := query.Get("filter"), query.Get("page"), query.Get("limit"); f != "neo" || p != "2" || l != "10" {
+			t.Errorf("Query = %v, expected filter neo on page 2 with limit 10", query)
+		}
+
+		fmt.Fprintf(w, `{"data": [`+testLibraryData+`]}`, "https://example.com/neopixel.tar.gz")
+	})
+
+	libraries, err := client.ListLibraries(LibraryListOptions{Filter: "neo", Page: 2, Limit: 10})
+
+	if err != nil {
+		t.Fatalf("ListLibraries(): %v", err)
+	}
+
+	expected := Libraries{{
+		ID:            "neopixel",
+		Name:          "neopixel",
+		Version:       "0.0.10",
+		License:       "MIT",
+		Architectures: []string{"particle-photon"},
+		Visibility:    "public",
+		Verified:      true,
+		Installs:      1000,
+		DownloadURL:   "https://example.com/neopixel.tar.gz",
+	}}
+
+	if !reflect.DeepEqual(libraries, expected) {
+		t.Errorf("Libraries = %v, expected %v", libraries, expected)
+	}
+}
+
+func TestClient_DownloadLibrary(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(libraryURL+"/neopixel", func(w http.ResponseWriter, r *http.Request) {
+		if v := r.URL.Query().Get("version"); v != "0.0.10" {
+			t.Errorf("Query version = %v, expected 0.0.10", v)
+		}
+
+		fmt.Fprintf(w, `{"data": `+testLibraryData+`}`, server.URL+"/archives/neopixel.tar.gz")
+	})
+
+	mux.HandleFunc("/archives/neopixel.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "archive")
+	})
+
+	var buf bytes.Buffer
+	err := client.DownloadLibrary("neopixel", "0.0.10", &buf)
+
+	if err != nil {
+		t.Fatalf("DownloadLibrary(): %v", err)
+	}
+
+	if buf.String() != "archive" {
+		t.Errorf("Downloaded %v, expected archive", buf.String())
+	}
+}
+
+func TestParseLibraryProperties(t *testing.T) {
+	props, err := ParseLibraryProperties(strings.NewReader(testLibraryProperties))
+
+	if err != nil {
+		t.Fatalf("ParseLibraryProperties(): %v", err)
+	}
+
+	expected := LibraryProperties{
+		Name:          "neopixel",
+		Version:       "0.0.10",
+		Author:        "Adafruit",
+		License:       "MIT",
+		Sentence:      "An Implementation of Adafruit's NeoPixel Library for the Spark Core",
+		Architectures: []string{"particle-photon", "particle-electron"},
+		Dependencies:  map[string]string{"other": "1.2.3"},
+	}
+
+	if !reflect.DeepEqual(props, expected) {
+		t.Errorf("Properties = %v, expected %v", props, expected)
+	}
+
+	if _, err := ParseLibraryProperties(strings.NewReader("author=me")); err == nil {
+		t.Errorf("ParseLibraryProperties() accepted properties without name and version")
+	}
+}
+
+func TestClient_UploadLibrary(t *testing.T) {
+	setup()
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "library")
+
+	if err != nil {
+		t.Fatalf("Could not create library directory: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		libraryPropertiesFile:      testLibraryProperties,
+		"src/neopixel.h":           "#pragma once",
+		".git/HEAD":                "ref: refs/heads/master",
+		"examples/simple/simple.c": "void setup() {}",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Could not write %v: %v", name, err)
+		}
+	}
+
+	mux.HandleFunc(libraryURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		f, _, err := r.FormFile("archive")
+
+		if err != nil {
+			t.Fatalf("Could not read archive: %v", err)
+		}
+
+		gz, err := gzip.NewReader(f)
+
+		if err != nil {
+			t.Fatalf("Archive isn't gzipped: %v", err)
+		}
+
+		var names []string
+		archive := tar.NewReader(gz)
+
+		for {
+			header, err := archive.Next()
+
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("Could not read archive: %v", err)
+			}
+
+			if header.Typeflag == tar.TypeReg {
+				names = append(names, header.Name)
+			}
+		}
+
+		sort.Strings(names)
+		expected := []string{"examples/simple/simple.c", libraryPropertiesFile, "src/neopixel.h"}
+
+		if !reflect.DeepEqual(names, expected) {
+			t.Errorf("Archive contains %v, expected %v", names, expected)
+		}
+
+		fmt.Fprintf(w, `{"data": `+testLibraryData+`}`, "")
+	})
+
+	lib, err := client.UploadLibrary(dir)
+
+	if err != nil {
+		t.Fatalf("UploadLibrary(): %v", err)
+	}
+
+	if lib.Name != "neopixel" || lib.Version != "0.0.10" {
+		t.Errorf("Uploaded library = %v, expected neopixel 0.0.10", lib)
+	}
+}
+
+func TestClient_PublishLibrary(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(libraryURL+"/neopixel", func(w http.ResponseWriter, r *http.Request) {
+		if m := "PATCH"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		if v := r.PostFormValue("visibility"); v != "public" {
+			t.Errorf("Form value visibility = %v, expected public", v)
+		}
+
+		fmt.Fprintf(w, `{"data": `+testLibraryData+`}`, "")
+	})
+
+	lib, err := client.PublishLibrary("neopixel")
+
+	if err != nil {
+		t.Fatalf("PublishLibrary(): %v", err)
+	}
+
+	if lib.Visibility != "public" {
+		t.Errorf("Library visibility = %v, expected public", lib.Visibility)
+	}
+}
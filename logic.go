@@ -0,0 +1,239 @@
+package particle
+
+import (
+	"time"
+)
+
+const (
+	logicFunctionURL = "/v1/logic/functions"
+	logicExecuteURL  = "/v1/logic/execute"
+)
+
+// LogicTriggerType is the kind of trigger which starts a logic function.
+type LogicTriggerType string
+
+// The trigger types a logic function can have.
+const (
+	LogicTriggerEvent        LogicTriggerType = "Event"
+	LogicTriggerScheduled    LogicTriggerType = "Scheduled"
+	LogicTriggerLedgerChange LogicTriggerType = "LedgerChange"
+)
+
+// LogicSource is the code of a logic function.
+type LogicSource struct {
+	Type string `json:"type"`
+	Code string `json:"code"`
+}
+
+// LogicTrigger starts a logic function. Depending on the type, only some of the fields are used: EventName and
+// ProductID for event triggers, Cron, StartAt and EndAt for scheduled triggers and LedgerName and ChangeType for
+// ledger change triggers.
+type LogicTrigger struct {
+	Type       LogicTriggerType `json:"type"`
+	Enabled    bool             `json:"enabled"`
+	EventName  string           `json:"event_name,omitempty"`
+	ProductID  int              `json:"product_id,omitempty"`
+	Cron       string           `json:"cron,omitempty"`
+	StartAt    *time.Time       `json:"start_at,omitempty"`
+	EndAt      *time.Time       `json:"end_at,omitempty"`
+	LedgerName string           `json:"ledger_name,omitempty"`
+	ChangeType string           `json:"change_type,omitempty"`
+}
+
+// LogicFunction is a function which runs in the particle cloud.
+type LogicFunction struct {
+	ID          string         `json:"id,omitempty"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Enabled     bool           `json:"enabled"`
+	Source      LogicSource    `json:"source"`
+	Triggers    []LogicTrigger `json:"logic_triggers"`
+	Version     int            `json:"version,omitempty"`
+	CreatedAt   *time.Time     `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time     `json:"updated_at,omitempty"`
+	client      *Client
+}
+
+// LogicFunctions is an array of the LogicFunction type.
+type LogicFunctions []LogicFunction
+
+// LogicRun is a single execution of a logic function.
+type LogicRun struct {
+	ID          string
+	Status      string
+	StartedAt   time.Time `json:"started_at"`
+	FinishedAt  time.Time `json:"finished_at"`
+	LogFilename string    `json:"log_filename"`
+}
+
+// LogicLog is a single log line a logic function wrote.
+type LogicLog struct {
+	Level     string
+	Message   string
+	Timestamp time.Time
+}
+
+// LogicEvent is the event a logic function is executed with.
+type LogicEvent struct {
+	EventName string `json:"event_name"`
+	EventData string `json:"event_data"`
+	DeviceID  string `json:"device_id,omitempty"`
+	ProductID int    `json:"product_id,omitempty"`
+}
+
+// LogicResult is the outcome of executing a logic function on demand.
+type LogicResult struct {
+	Status string
+	Logs   []LogicLog
+	Err    string
+}
+
+// logicFunctionBody is the envelope the API wraps single logic functions in.
+type logicFunctionBody struct {
+	LogicFunction LogicFunction `json:"logic_function"`
+}
+
+// ListLogicFunctions lists the logic functions of the user.
+func (c *Client) ListLogicFunctions() (LogicFunctions, error) {
+	var resp struct {
+		LogicFunctions LogicFunctions `json:"logic_functions"`
+	}
+
+	_, err := c.get(logicFunctionURL, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range resp.LogicFunctions {
+		resp.LogicFunctions[idx].client = c
+	}
+
+	return resp.LogicFunctions, err
+}
+
+// GetLogicFunction gets a single logic function by it's id.
+func (c *Client) GetLogicFunction(id string) (LogicFunction, error) {
+	var resp logicFunctionBody
+	_, err := c.get(logicFunctionURL+"/"+id, &resp)
+
+	if err != nil {
+		return resp.LogicFunction, err
+	}
+
+	resp.LogicFunction.client = c
+
+	return resp.LogicFunction, err
+}
+
+// CreateLogicFunction creates a new logic function and returns it. Functions without a source type are created as
+// JavaScript.
+func (c *Client) CreateLogicFunction(f LogicFunction) (LogicFunction, error) {
+	if f.Source.Type == "" {
+		f.Source.Type = "JavaScript"
+	}
+
+	var resp logicFunctionBody
+	_, err := c.postJSON(logicFunctionURL, logicFunctionBody{f}, &resp)
+
+	if err != nil {
+		return resp.LogicFunction, err
+	}
+
+	resp.LogicFunction.client = c
+
+	return resp.LogicFunction, err
+}
+
+// endPoint returns the API end point of the logic function.
+func (f *LogicFunction) endPoint() string {
+	return logicFunctionURL + "/" + f.ID
+}
+
+// Update saves the current state of the logic function, including it's triggers, to the cloud.
+func (f *LogicFunction) Update() error {
+	body := logicFunctionBody{*f}
+	_, err := f.client.putJSON(f.endPoint(), body, &body)
+
+	if err != nil {
+		return err
+	}
+
+	body.LogicFunction.client = f.client
+	*f = body.LogicFunction
+
+	return nil
+}
+
+// SetTriggers replaces the triggers of the logic function.
+func (f *LogicFunction) SetTriggers(triggers ...LogicTrigger) error {
+	f.Triggers = triggers
+
+	return f.Update()
+}
+
+// Enable enables the logic function, so it's started by it's triggers.
+func (f *LogicFunction) Enable() error {
+	f.Enabled = true
+
+	return f.Update()
+}
+
+// Disable disables the logic function.
+func (f *LogicFunction) Disable() error {
+	f.Enabled = false
+
+	return f.Update()
+}
+
+// Delete deletes the logic function.
+func (f *LogicFunction) Delete() error {
+	var resp okResponse
+	_, err := f.client.delete(f.endPoint(), &resp)
+
+	return err
+}
+
+// Runs lists the recent runs of the logic function.
+func (f *LogicFunction) Runs() ([]LogicRun, error) {
+	var resp struct {
+		LogicRuns []LogicRun `json:"logic_runs"`
+	}
+
+	_, err := f.client.get(f.endPoint()+"/runs", &resp)
+
+	return resp.LogicRuns, err
+}
+
+// RunLogs returns the logs of the run with the given id.
+func (f *LogicFunction) RunLogs(runID string) ([]LogicLog, error) {
+	var resp struct {
+		Logs []LogicLog
+	}
+
+	_, err := f.client.get(f.endPoint()+"/runs/"+runID+"/logs", &resp)
+
+	return resp.Logs, err
+}
+
+// Execute runs the current source of the logic function once with the given test event. The function doesn't need to
+// be saved or enabled for this.
+func (f *LogicFunction) Execute(e LogicEvent) (LogicResult, error) {
+	var body struct {
+		Logic struct {
+			Source LogicSource `json:"source"`
+		} `json:"logic"`
+		Event LogicEvent `json:"event"`
+	}
+
+	body.Logic.Source = f.Source
+	body.Event = e
+
+	var resp struct {
+		Result LogicResult
+	}
+
+	_, err := f.client.postJSON(logicExecuteURL, body, &resp)
+
+	return resp.Result, err
+}
@@ -0,0 +1,216 @@
+package particle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// generateTestLogicFunction generates a logic function for testing.
+func generateTestLogicFunction(id, name string) LogicFunction {
+	f := LogicFunction{
+		ID:       id,
+		Name:     name,
+		Enabled:  true,
+		Source:   LogicSource{"JavaScript", "export default function main() {}"},
+		Triggers: []LogicTrigger{{Type: LogicTriggerEvent, Enabled: true, EventName: "temperature"}},
+		client:   client,
+	}
+
+	return f
+}
+
+func TestClient_ListLogicFunctions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	functions := LogicFunctions{generateTestLogicFunction("1", "alert"), generateTestLogicFunction("2", "report")}
+
+	mux.HandleFunc(logicFunctionURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		err := json.NewEncoder(w).Encode(map[string]LogicFunctions{"logic_functions": functions})
+
+		if err != nil {
+			t.Fatalf("Could not encode logic functions: %v", err)
+		}
+	})
+
+	functionsResp, err := client.ListLogicFunctions()
+
+	if err != nil {
+		t.Fatalf("ListLogicFunctions(): %v", err)
+	}
+
+	if !reflect.DeepEqual(functionsResp, functions) {
+		t.Errorf("Response logic functions %v don't match with originals: %v", functionsResp, functions)
+	}
+}
+
+func TestClient_CreateLogicFunction(t *testing.T) {
+	setup()
+	defer teardown()
+
+	f := generateTestLogicFunction("", "alert")
+	f.Source.Type = ""
+
+	mux.HandleFunc(logicFunctionURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		var body logicFunctionBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Could not decode logic function: %v", err)
+		}
+
+		if s := body.LogicFunction.Source.Type; s != "JavaScript" {
+			t.Errorf("Source type = %v, expected JavaScript", s)
+		}
+
+		body.LogicFunction.ID = "42"
+		json.NewEncoder(w).Encode(body)
+	})
+
+	created, err := client.CreateLogicFunction(f)
+
+	if err != nil {
+		t.Fatalf("CreateLogicFunction(): %v", err)
+	}
+
+	if created.ID != "42" || created.client != client {
+		t.Errorf("Created logic function = %v, expected id 42 bound to the client", created)
+	}
+}
+
+func TestLogicFunction_SetTriggersAndDisable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	f := generateTestLogicFunction("1", "report")
+	var received []LogicFunction
+
+	mux.HandleFunc(f.endPoint(), func(w http.ResponseWriter, r *http.Request) {
+		if m := "PUT"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		var body logicFunctionBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Could not decode logic function: %v", err)
+		}
+
+		received = append(received, body.LogicFunction)
+		body.LogicFunction.Version++
+		json.NewEncoder(w).Encode(body)
+	})
+
+	schedule := LogicTrigger{Type: LogicTriggerScheduled, Enabled: true, Cron: "0 * * * *"}
+
+	if err := f.SetTriggers(schedule); err != nil {
+		t.Fatalf("SetTriggers(): %v", err)
+	}
+
+	if err := f.Disable(); err != nil {
+		t.Fatalf("Disable(): %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("Received %v updates, expected 2", len(received))
+	}
+
+	if tr := received[0].Triggers; len(tr) != 1 || tr[0].Cron != schedule.Cron {
+		t.Errorf("Triggers = %v, expected %v", tr, schedule)
+	}
+
+	if received[1].Enabled {
+		t.Errorf("Logic function is still enabled after Disable()")
+	}
+
+	if f.Version != 2 || f.client != client {
+		t.Errorf("Logic function = %v, expected version 2 bound to the client", f)
+	}
+}
+
+func TestLogicFunction_RunLogs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	f := generateTestLogicFunction("1", "report")
+
+	mux.HandleFunc(f.endPoint()+"/runs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"logic_runs": [{"id": "r1", "status": "Success", "started_at": "2016-08-01T12:30:00Z"}]}`)
+	})
+
+	mux.HandleFunc(f.endPoint()+"/runs/r1/logs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"logs": [{"level": "Info", "message": "hello", "timestamp": "2016-08-01T12:30:01Z"}]}`)
+	})
+
+	runs, err := f.Runs()
+
+	if err != nil {
+		t.Fatalf("Runs(): %v", err)
+	}
+
+	if len(runs) != 1 || runs[0].Status != "Success" {
+		t.Fatalf("Runs = %v, expected a single successful run", runs)
+	}
+
+	logs, err := f.RunLogs(runs[0].ID)
+
+	if err != nil {
+		t.Fatalf("RunLogs(): %v", err)
+	}
+
+	if len(logs) != 1 || logs[0].Message != "hello" {
+		t.Errorf("Logs = %v, expected a single hello", logs)
+	}
+}
+
+func TestLogicFunction_Execute(t *testing.T) {
+	setup()
+	defer teardown()
+
+	f := generateTestLogicFunction("1", "report")
+
+	mux.HandleFunc(logicExecuteURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		var body struct {
+			Logic struct {
+				Source LogicSource
+			}
+			Event LogicEvent
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Could not decode execution: %v", err)
+		}
+
+		if body.Logic.Source != f.Source {
+			t.Errorf("Source = %v, expected %v", body.Logic.Source, f.Source)
+		}
+
+		if body.Event.EventName != "temperature" {
+			t.Errorf("Event name = %v, expected temperature", body.Event.EventName)
+		}
+
+		fmt.Fprint(w, `{"result": {"status": "Success", "logs": [{"level": "Info", "message": "21.5"}]}}`)
+	})
+
+	result, err := f.Execute(LogicEvent{EventName: "temperature", EventData: "21.5", DeviceID: "1"})
+
+	if err != nil {
+		t.Fatalf("Execute(): %v", err)
+	}
+
+	if result.Status != "Success" || len(result.Logs) != 1 {
+		t.Errorf("Result = %v, expected success with one log line", result)
+	}
+}
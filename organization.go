@@ -0,0 +1,153 @@
+package particle
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+)
+
+const organizationURL = "/v1/orgs"
+
+// Organization information
+type Organization struct {
+	ID     string
+	Slug   string
+	Name   string
+	client *Client
+}
+
+// Organizations is an array of the Organization type.
+type Organizations []Organization
+
+// TeamRole is the role of a team member, which defines what the member is allowed to do.
+type TeamRole string
+
+// The roles a team member can have.
+const (
+	RoleAdministrator TeamRole = "Administrator"
+	RoleDeveloper     TeamRole = "Developer"
+	RoleSupport       TeamRole = "Support"
+	RoleViewOnly      TeamRole = "View-only"
+)
+
+// UnmarshalJSON decodes the role either from it's name or from a role object like {"id": "...", "name": "Developer"}.
+func (r *TeamRole) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var role struct {
+			Name string
+		}
+
+		err := json.Unmarshal(data, &role)
+		*r = TeamRole(role.Name)
+
+		return err
+	}
+
+	var name string
+	err := json.Unmarshal(data, &name)
+	*r = TeamRole(name)
+
+	return err
+}
+
+// InviteStatus tells whether a team member already accepted the invitation.
+type InviteStatus string
+
+// The invite states of a team member.
+const (
+	InvitePending  InviteStatus = "pending"
+	InviteAccepted InviteStatus = "accepted"
+)
+
+// TeamMember is a member of a products team.
+type TeamMember struct {
+	Username   string
+	Role       TeamRole
+	Status     InviteStatus
+	TFAEnabled bool `json:"tfa_enabled"`
+}
+
+// TeamMembers is an array of the TeamMember type.
+type TeamMembers []TeamMember
+
+// ListOrganizations lists the organizations the user is a member of.
+func (c *Client) ListOrganizations() (Organizations, error) {
+	var resp struct {
+		Organizations Organizations
+	}
+
+	_, err := c.get(organizationURL, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range resp.Organizations {
+		resp.Organizations[idx].client = c
+	}
+
+	return resp.Organizations, err
+}
+
+// ListProducts lists the products of the organization.
+func (o *Organization) ListProducts() (Products, error) {
+	var resp productsResponse
+	_, err := o.client.get(organizationURL+"/"+o.ID+"/products", &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range resp.Products {
+		resp.Products[idx].client = o.client
+	}
+
+	return resp.Products, err
+}
+
+// teamURL returns the end point of the products team.
+func (p *Product) teamURL() string {
+	return p.endPoint() + "/team"
+}
+
+// ListTeamMembers lists the members of the products team, including the ones who didn't accept their invitation yet.
+func (p *Product) ListTeamMembers() (TeamMembers, error) {
+	var resp struct {
+		Team TeamMembers
+	}
+
+	_, err := p.client.get(p.teamURL(), &resp)
+
+	return resp.Team, err
+}
+
+// InviteTeamMember invites the user with the given username to the products team.
+func (p *Product) InviteTeamMember(username string, role TeamRole) (TeamMember, error) {
+	form := url.Values{}
+	form.Add("username", username)
+	form.Add("role", string(role))
+
+	var member TeamMember
+	_, err := p.client.post(p.teamURL(), form, &member)
+
+	return member, err
+}
+
+// UpdateTeamMemberRole changes the role of the team member with the given username.
+func (p *Product) UpdateTeamMemberRole(username string, role TeamRole) (TeamMember, error) {
+	form := url.Values{}
+	form.Add("role", string(role))
+
+	var member TeamMember
+	_, err := p.client.post(p.teamURL()+"/"+url.PathEscape(username), form, &member)
+
+	return member, err
+}
+
+// RemoveTeamMember removes the member with the given username from the products team.
+func (p *Product) RemoveTeamMember(username string) error {
+	var resp okResponse
+	_, err := p.client.delete(p.teamURL()+"/"+url.PathEscape(username), &resp)
+
+	return err
+}
@@ -0,0 +1,147 @@
+package particle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_ListOrganizations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(organizationURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		fmt.Fprint(w, `{"organizations": [{"id": "o1", "slug": "acme", "name": "ACME"}]}`)
+	})
+
+	orgs, err := client.ListOrganizations()
+
+	if err != nil {
+		t.Fatalf("ListOrganizations(): %v", err)
+	}
+
+	expected := Organizations{{"o1", "acme", "ACME", client}}
+	if !reflect.DeepEqual(orgs, expected) {
+		t.Errorf("Organizations = %v, expected %v", orgs, expected)
+	}
+}
+
+func TestOrganization_ListProducts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	org := Organization{"o1", "acme", "ACME", client}
+	products := Products{generateTestProduct(1, "lamp")}
+
+	mux.HandleFunc(organizationURL+"/o1/products", func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(w).Encode(productsResponse{products})
+
+		if err != nil {
+			t.Fatalf("Could not encode products: %v", err)
+		}
+	})
+
+	productsResp, err := org.ListProducts()
+
+	if err != nil {
+		t.Fatalf("ListProducts(): %v", err)
+	}
+
+	if !reflect.DeepEqual(productsResp, products) {
+		t.Errorf("Response products %v don't match with originals: %v", productsResp, products)
+	}
+}
+
+func TestProduct_ListTeamMembers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	product := generateTestProduct(1, "lamp")
+
+	mux.HandleFunc(product.teamURL(), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"team": [
+			{"username": "jane@example.com", "role": {"id": "r1", "name": "Administrator"}, "status": "accepted"},
+			{"username": "joe@example.com", "role": "Developer", "status": "pending"}
+		]}`)
+	})
+
+	team, err := product.ListTeamMembers()
+
+	if err != nil {
+		t.Fatalf("ListTeamMembers(): %v", err)
+	}
+
+	expected := TeamMembers{
+		{Username: "jane@example.com", Role: RoleAdministrator, Status: InviteAccepted},
+		{Username: "joe@example.com", Role: RoleDeveloper, Status: InvitePending},
+	}
+
+	if !reflect.DeepEqual(team, expected) {
+		t.Errorf("Team = %v, expected %v", team, expected)
+	}
+}
+
+func TestProduct_ManageTeamMember(t *testing.T) {
+	setup()
+	defer teardown()
+
+	product := generateTestProduct(1, "lamp")
+	var calls []string
+
+	mux.HandleFunc(product.teamURL(), func(w http.ResponseWriter, r *http.Request) {
+		if m := "POST"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		calls = append(calls, "invite "+r.PostFormValue("username")+" "+r.PostFormValue("role"))
+		fmt.Fprint(w, `{"username": "joe@example.com", "role": "Support", "status": "pending"}`)
+	})
+
+	mux.HandleFunc(product.teamURL()+"/joe@example.com", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			calls = append(calls, "update "+r.PostFormValue("role"))
+			fmt.Fprint(w, `{"username": "joe@example.com", "role": "Developer", "status": "accepted"}`)
+		case "DELETE":
+			calls = append(calls, "remove")
+			fmt.Fprint(w, `{"ok": true}`)
+		default:
+			t.Errorf("Unexpected request method %v", r.Method)
+		}
+	})
+
+	member, err := product.InviteTeamMember("joe@example.com", RoleSupport)
+
+	if err != nil {
+		t.Fatalf("InviteTeamMember(): %v", err)
+	}
+
+	if member.Status != InvitePending {
+		t.Errorf("Invited member has status %v, expected %v", member.Status, InvitePending)
+	}
+
+	member, err = product.UpdateTeamMemberRole("joe@example.com", RoleDeveloper)
+
+	if err != nil {
+		t.Fatalf("UpdateTeamMemberRole(): %v", err)
+	}
+
+	if member.Role != RoleDeveloper {
+		t.Errorf("Updated member has role %v, expected %v", member.Role, RoleDeveloper)
+	}
+
+	if err := product.RemoveTeamMember("joe@example.com"); err != nil {
+		t.Fatalf("RemoveTeamMember(): %v", err)
+	}
+
+	expected := []string{"invite joe@example.com Support", "update Developer", "remove"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Calls = %v, expected %v", calls, expected)
+	}
+}
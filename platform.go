@@ -0,0 +1,126 @@
+package particle
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const buildTargetsURL = "/v1/build_targets"
+
+// Platform is the hardware platform of a device.
+type Platform int
+
+// The platforms known by this library.
+const (
+	PlatformCore     Platform = 0
+	PlatformPhoton   Platform = 6
+	PlatformP1       Platform = 8
+	PlatformElectron Platform = 10
+	PlatformArgon    Platform = 12
+	PlatformBoron    Platform = 13
+	PlatformXenon    Platform = 14
+	PlatformASoM     Platform = 22
+	PlatformBSoM     Platform = 23
+	PlatformB5SoM    Platform = 25
+	PlatformTracker  Platform = 26
+	PlatformP2       Platform = 32
+)
+
+// platformNames maps the known platforms to their names.
+var platformNames = map[Platform]string{
+	PlatformCore:     "Core",
+	PlatformPhoton:   "Photon",
+	PlatformP1:       "P1",
+	PlatformElectron: "Electron",
+	PlatformArgon:    "Argon",
+	PlatformBoron:    "Boron",
+	PlatformXenon:    "Xenon",
+	PlatformASoM:     "A SoM",
+	PlatformBSoM:     "B SoM",
+	PlatformB5SoM:    "B5 SoM",
+	PlatformTracker:  "Tracker",
+	PlatformP2:       "P2",
+}
+
+// String returns the name of the platform.
+func (p Platform) String() string {
+	if name, ok := platformNames[p]; ok {
+		return name
+	}
+
+	return "Platform(" + strconv.Itoa(int(p)) + ")"
+}
+
+// Known reports whether the platform is one of the platforms known by this library.
+func (p Platform) Known() bool {
+	_, ok := platformNames[p]
+	return ok
+}
+
+// ParsePlatform returns the platform with the given name, e.g. "Photon".
+func ParsePlatform(name string) (Platform, error) {
+	for p, n := range platformNames {
+		if n == name {
+			return p, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown platform %v", name)
+}
+
+// BuildTarget is a Device OS version and the platforms it can be built for.
+type BuildTarget struct {
+	Version        string
+	FirmwareVendor string `json:"firmware_vendor"`
+	Platforms      []Platform
+	Prereleases    []Platform
+}
+
+// BuildTargets is an array of the BuildTarget type.
+type BuildTargets []BuildTarget
+
+// BuildCatalog holds the build targets offered by the cloud and the names of the platforms they refer to.
+type BuildCatalog struct {
+	Targets   BuildTargets
+	Platforms map[string]Platform
+}
+
+// GetBuildCatalog fetches the available build targets from the cloud. If featured is true, then only the versions
+// recommended by particle are returned.
+func (c *Client) GetBuildCatalog(featured bool) (BuildCatalog, error) {
+	var catalog BuildCatalog
+	endPoint := buildTargetsURL
+
+	if featured {
+		endPoint += "?featured=true"
+	}
+
+	_, err := c.get(endPoint, &catalog)
+
+	return catalog, err
+}
+
+// Versions returns the Device OS versions which are available for the given platform. Prereleases are only included
+// if prereleases is true.
+func (catalog BuildCatalog) Versions(p Platform, prereleases bool) []string {
+	var versions []string
+
+	for _, target := range catalog.Targets {
+		if containsPlatform(target.Platforms, p) || (prereleases && containsPlatform(target.Prereleases, p)) {
+			versions = append(versions, target.Version)
+		}
+	}
+
+	return versions
+}
+
+// containsPlatform reports whether the given platform is part of the list.
+func containsPlatform(platforms []Platform, p Platform) bool {
+	for _, platform := range platforms {
+		if platform == p {
+			return true
+		}
+	}
+
+	return false
+}
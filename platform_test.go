@@ -0,0 +1,69 @@
+package particle
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestPlatform_String(t *testing.T) {
+	tests := map[Platform]string{
+		PlatformCore:     "Core",
+		PlatformElectron: "Electron",
+		PlatformBoron:    "Boron",
+		Platform(99):     "Platform(99)",
+	}
+
+	for p, name := range tests {
+		if p.String() != name {
+			t.Errorf("Platform %d has name %v, expected %v", int(p), p.String(), name)
+		}
+	}
+
+	if p, err := ParsePlatform("Argon"); err != nil || p != PlatformArgon {
+		t.Errorf("ParsePlatform(Argon) = %v, %v, expected %v", p, err, PlatformArgon)
+	}
+
+	if _, err := ParsePlatform("Toaster"); err == nil {
+		t.Errorf("ParsePlatform(Toaster) returned no error")
+	}
+}
+
+func TestClient_GetBuildCatalog(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(buildTargetsURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		if f := r.URL.Query().Get("featured"); f != "true" {
+			t.Errorf("Query featured = %v, expected true", f)
+		}
+
+		fmt.Fprint(w, `{"targets": [
+			{"version": "0.6.0", "firmware_vendor": "Particle", "platforms": [0, 6, 10], "prereleases": []},
+			{"version": "0.6.1-rc.1", "firmware_vendor": "Particle", "platforms": [6], "prereleases": [10]}
+		], "platforms": {"Core": 0, "Photon": 6, "Electron": 10}}`)
+	})
+
+	catalog, err := client.GetBuildCatalog(true)
+
+	if err != nil {
+		t.Fatalf("GetBuildCatalog(): %v", err)
+	}
+
+	if p := catalog.Platforms["Electron"]; p != PlatformElectron {
+		t.Errorf("Catalog platform Electron = %v, expected %v", p, PlatformElectron)
+	}
+
+	if v := catalog.Versions(PlatformElectron, false); !reflect.DeepEqual(v, []string{"0.6.0"}) {
+		t.Errorf("Electron versions = %v, expected [0.6.0]", v)
+	}
+
+	if v := catalog.Versions(PlatformElectron, true); !reflect.DeepEqual(v, []string{"0.6.0", "0.6.1-rc.1"}) {
+		t.Errorf("Electron versions including prereleases = %v, expected [0.6.0 0.6.1-rc.1]", v)
+	}
+}
@@ -0,0 +1,123 @@
+package particle
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const productURL = "/v1/products"
+
+// Product information
+type Product struct {
+	ID             int
+	Name           string
+	Slug           string
+	Description    string
+	PlatformID     int `json:"platform_id"`
+	SubscriptionID int `json:"subscription_id"`
+	client         *Client
+}
+
+// Products is an array of the Product type.
+type Products []Product
+
+// productsResponse is the envelope the API wraps product lists in.
+type productsResponse struct {
+	Products Products
+}
+
+// productResponse is the envelope the API wraps a single product in.
+type productResponse struct {
+	Product Product
+}
+
+// ListProducts lists the products the token has access to.
+func (c *Client) ListProducts() (Products, error) {
+	var resp productsResponse
+	_, err := c.get(productURL, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range resp.Products {
+		resp.Products[idx].client = c
+	}
+
+	return resp.Products, err
+}
+
+// GetProduct gets a single product by it's id or slug.
+func (c *Client) GetProduct(idOrSlug string) (Product, error) {
+	var resp productResponse
+	_, err := c.get(productURL+"/"+idOrSlug, &resp)
+
+	if err != nil {
+		return resp.Product, err
+	}
+
+	resp.Product.client = c
+
+	return resp.Product, err
+}
+
+// endPoint returns the API end point of the product, which all product specific requests are relative to.
+func (p *Product) endPoint() string {
+	return productURL + "/" + strconv.Itoa(p.ID)
+}
+
+// GetDevice gets a single device of the product by it's id.
+func (p *Product) GetDevice(id string) (Device, error) {
+	var device Device
+	_, err := p.client.get(p.endPoint()+"/devices/"+id, &device)
+
+	if err != nil {
+		return device, err
+	}
+
+	device.client = p.client
+
+	return device, err
+}
+
+// productDevicesResponse is the envelope the API wraps a page of product devices in.
+type productDevicesResponse struct {
+	Devices Devices
+	Meta    struct {
+		TotalPages int `json:"total_pages"`
+	}
+}
+
+// ListDevices lists the devices of the product. If groups are passed, then only the devices belonging to at least one
+// of them are listed. All pages are fetched before returning.
+func (p *Product) ListDevices(groups ...string) (Devices, error) {
+	var devices Devices
+	query := url.Values{}
+
+	if len(groups) > 0 {
+		query.Set("groups", strings.Join(groups, ","))
+	}
+
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		var resp productDevicesResponse
+		_, err := p.client.get(p.endPoint()+"/devices?"+query.Encode(), &resp)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for idx := range resp.Devices {
+			resp.Devices[idx].client = p.client
+		}
+
+		devices = append(devices, resp.Devices...)
+
+		if page >= resp.Meta.TotalPages {
+			break
+		}
+	}
+
+	return devices, nil
+}
@@ -0,0 +1,114 @@
+package particle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// generateTestProduct generates a product for testing.
+func generateTestProduct(id int, name string) Product {
+	product := Product{
+		ID:         id,
+		Name:       name,
+		Slug:       name + "-v100",
+		PlatformID: 6,
+		client:     client,
+	}
+
+	return product
+}
+
+func TestClient_ListProducts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	products := Products{generateTestProduct(1, "lamp"), generateTestProduct(2, "kettle")}
+
+	mux.HandleFunc(productURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		err := json.NewEncoder(w).Encode(productsResponse{products})
+
+		if err != nil {
+			t.Fatalf("Could not encode products: %v", err)
+		}
+	})
+
+	productsResp, err := client.ListProducts()
+
+	if err != nil {
+		t.Fatalf("ListProducts(): %v", err)
+	}
+
+	if !reflect.DeepEqual(productsResp, products) {
+		t.Errorf("Response products %v don't match with originals: %v", productsResp, products)
+	}
+}
+
+func TestClient_GetProduct(t *testing.T) {
+	setup()
+	defer teardown()
+
+	product := generateTestProduct(1, "lamp")
+
+	mux.HandleFunc(productURL+"/"+product.Slug, func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		err := json.NewEncoder(w).Encode(productResponse{product})
+
+		if err != nil {
+			t.Fatalf("Could not encode product: %v", err)
+		}
+	})
+
+	productResp, err := client.GetProduct(product.Slug)
+
+	if err != nil {
+		t.Fatalf("GetProduct(): %v", err)
+	}
+
+	if !reflect.DeepEqual(productResp, product) {
+		t.Errorf("Response product %v doesn't match orignal: %v", productResp, product)
+	}
+}
+
+func TestProduct_ListDevices(t *testing.T) {
+	setup()
+	defer teardown()
+
+	product := generateTestProduct(1, "lamp")
+
+	mux.HandleFunc(product.endPoint()+"/devices", func(w http.ResponseWriter, r *http.Request) {
+		if groups := r.URL.Query().Get("groups"); groups != "beta,lab" {
+			t.Errorf("Groups = %v, expected beta,lab", groups)
+		}
+
+		page := r.URL.Query().Get("page")
+		fmt.Fprintf(w, `{"devices": [{"id": "%v", "groups": ["beta"]}], "meta": {"total_pages": 2}}`, page)
+	})
+
+	devices, err := product.ListDevices("beta", "lab")
+
+	if err != nil {
+		t.Fatalf("ListDevices(): %v", err)
+	}
+
+	if len(devices) != 2 || devices[0].ID != "1" || devices[1].ID != "2" {
+		t.Errorf("ListDevices() = %v, expected the devices of both pages", devices)
+	}
+
+	beta := devices.Select(func(d Device) bool {
+		return d.ID == "2" && len(d.Groups) == 1 && d.Groups[0] == "beta"
+	})
+
+	if len(beta) != 1 || beta[0].client != client {
+		t.Errorf("Select() = %v, expected device 2 with it's client", beta)
+	}
+}
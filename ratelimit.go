@@ -0,0 +1,67 @@
+package particle
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces requests evenly, so that no more than a given number of requests per second are made. It's safe
+// to change the limit while requests are made.
+type rateLimiter struct {
+	mu sync.Mutex
+	// interval between two requests, 0 doesn't limit at all.
+	interval time.Duration
+	next     time.Time
+}
+
+// set changes the limit to the given number of requests per second. A limit of 0 or less removes the limit.
+func (l *rateLimiter) set(perSecond float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.interval = 0
+	l.next = time.Time{}
+
+	if perSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+}
+
+// wait blocks until the next request may be made. A nil rateLimiter doesn't limit at all.
+func (l *rateLimiter) wait() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+
+	if l.interval == 0 {
+		l.mu.Unlock()
+		return
+	}
+
+	now := time.Now()
+
+	if l.next.Before(now) {
+		l.next = now
+	}
+
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(delay)
+}
+
+// SetRateLimit limits the client to the given number of requests per second, which is shared by all goroutines
+// using the client. A limit of 0 or less removes the limit. The limit may be changed while the client is in use,
+// e.g. during a batch operation. The particle cloud rejects clients which make too many requests, so bulk operations
+// should set a limit.
+func (c *Client) SetRateLimit(perSecond float64) {
+	// Clients created without NewClient get their limiter on first use, which isn't safe while they're in use.
+	if c.limiter == nil {
+		c.limiter = &rateLimiter{}
+	}
+
+	c.limiter.set(perSecond)
+}
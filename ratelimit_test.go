@@ -0,0 +1,67 @@
+package particle
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_SetRateLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok": true}`)
+	})
+
+	client.SetRateLimit(50)
+	start := time.Now()
+
+	for i := 0; i < 6; i++ {
+		var resp okResponse
+		if _, err := client.get("/", &resp); err != nil {
+			t.Fatalf("client.Get(): %v", err)
+		}
+	}
+
+	// The first request passes immediately, the other 5 have to wait 20ms each.
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("6 requests took %v with a limit of 50 per second, expected at least 100ms", elapsed)
+	}
+
+	client.SetRateLimit(0)
+	start = time.Now()
+
+	for i := 0; i < 6; i++ {
+		client.limiter.wait()
+	}
+
+	if elapsed := time.Since(start); elapsed > 10*time.Millisecond {
+		t.Errorf("6 requests took %v without limit, expected them to pass immediately", elapsed)
+	}
+}
+
+func TestClient_SetRateLimitConcurrently(t *testing.T) {
+	c := NewClient(nil, "foo")
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 10; j++ {
+				c.limiter.wait()
+			}
+		}()
+	}
+
+	for _, limit := range []float64{1000, 0, 2000} {
+		c.SetRateLimit(limit)
+	}
+
+	wg.Wait()
+}
@@ -21,9 +21,14 @@ func (r *ErrorResponse) Error() string {
 		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Message)
 }
 
-// CheckResponse checks the API response of an http.Response object.
+// okResponse is the body the API returns for requests which don't return any data.
+type okResponse struct {
+	Ok bool
+}
+
+// CheckResponse checks the API response of an http.Response object. Any 2xx status code is treated as success.
 func CheckResponse(r *http.Response) error {
-	if r.StatusCode == 200 {
+	if r.StatusCode >= 200 && r.StatusCode < 300 {
 		return nil
 	}
 
@@ -34,6 +39,15 @@ func CheckResponse(r *http.Response) error {
 		if err != nil {
 			errorResponse.Message = string(data)
 		}
+
+		// Most endpoints report the error message in the error field.
+		if errorResponse.Message == "" {
+			var body struct{ Error string }
+
+			if json.Unmarshal(data, &body) == nil {
+				errorResponse.Message = body.Error
+			}
+		}
 	}
 
 	return errorResponse
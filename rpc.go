@@ -0,0 +1,123 @@
+package particle
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultRPCTimeout is the time an RPC waits for the response event, if no other timeout was set.
+const defaultRPCTimeout = 10 * time.Second
+
+// ErrRPCTimeout is returned when a device didn't publish the response to an RPC in time.
+var ErrRPCTimeout = errors.New("no response event received in time")
+
+// RPC sends requests to a device and waits for the event the device publishes in response. Requests and responses are
+// matched by a correlation ID, which prefixes the data of both, separated by a colon: "<id>:<payload>". The device has
+// to copy the ID of the request into it's response.
+type RPC struct {
+	Device *Device
+	// ResponseEvent is the name, or prefix of the name, of the events the device publishes the responses under.
+	ResponseEvent string
+	// Timeout is the time to wait for the response, defaults to 10 seconds.
+	Timeout time.Duration
+}
+
+// NewRPC creates an RPC, which waits for responses published by the device under the given event name.
+func (d *Device) NewRPC(responseEvent string) *RPC {
+	return &RPC{Device: d, ResponseEvent: responseEvent}
+}
+
+// Publish publishes a private request event with the given name and payload, which the device has to subscribe to,
+// and returns the payload of the response event.
+func (r *RPC) Publish(event, payload string) (string, error) {
+	return r.roundTrip(func(id string) error {
+		return r.Device.client.PublishEvent(event, id+":"+payload, true)
+	})
+}
+
+// Call calls the function with the given name and payload and returns the payload of the response event. The ID and
+// payload have to fit into a single function argument. A negative return value of the function is treated as the
+// device rejecting the request.
+func (r *RPC) Call(function, payload string) (string, error) {
+	return r.roundTrip(func(id string) error {
+		resp, err := r.Device.CallFunctionWith(function, StringArg(id+":"+payload))
+
+		if err == nil && resp.ReturnValue < 0 {
+			err = fmt.Errorf("%v on device %v rejected the request with %v", function, r.Device.ID, resp.ReturnValue)
+		}
+
+		return err
+	})
+}
+
+// roundTrip subscribes to the response events, sends the request using the given function and waits for the response
+// with the requests correlation ID.
+func (r *RPC) roundTrip(send func(id string) error) (string, error) {
+	id, err := newCorrelationID()
+
+	if err != nil {
+		return "", err
+	}
+
+	// Subscribe before sending the request, so a quick response isn't missed.
+	e, err := r.Device.NewEventListener(r.ResponseEvent)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer e.Close()
+
+	listenErr := make(chan error, 1)
+
+	go func() {
+		listenErr <- e.Listen()
+	}()
+
+	if err := send(id); err != nil {
+		return "", err
+	}
+
+	timeout := r.Timeout
+
+	if timeout <= 0 {
+		timeout = defaultRPCTimeout
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	prefix := id + ":"
+
+	for {
+		select {
+		case ev, ok := <-e.OutputChan:
+			if !ok {
+				return "", fmt.Errorf("event stream of device %v closed: %v", r.Device.ID, <-listenErr)
+			}
+
+			if strings.HasPrefix(ev.Data, prefix) {
+				return strings.TrimPrefix(ev.Data, prefix), nil
+			}
+		case <-e.ErrorChan:
+			// Malformed events can't be the response, so they're skipped.
+		case <-timer.C:
+			return "", fmt.Errorf("request %v to device %v: %w", id, r.Device.ID, ErrRPCTimeout)
+		}
+	}
+}
+
+// newCorrelationID returns a random ID of 8 hex characters.
+func newCorrelationID() (string, error) {
+	b := make([]byte, 4)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,108 @@
+package particle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// handleRPCStream streams the response event of every request received over requests, preceded by a response with
+// a foreign correlation ID. Requests without "ping" payload aren't answered.
+func handleRPCStream(t *testing.T, requests chan string) {
+	mux.HandleFunc(deviceURL+"/1/events/rpc/response", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, ":ok\n\n")
+		w.(http.Flusher).Flush()
+
+		for {
+			select {
+			case req := <-requests:
+				parts := strings.SplitN(req, ":", 2)
+
+				if parts[1] != "ping" {
+					continue
+				}
+
+				for _, data := range []string{"00000000:pong", parts[0] + ":pong"} {
+					ev, err := json.Marshal(Event{"rpc/response", data, "60", time.Now(), "1"})
+
+					if err != nil {
+						t.Errorf("Error while encoding event: %v", err)
+					}
+
+					fmt.Fprintf(w, "event: rpc/response\ndata: %s\n\n", ev)
+				}
+
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+}
+
+func TestRPC_Publish(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := make(chan string, 1)
+	handleRPCStream(t, requests)
+
+	mux.HandleFunc(deviceURL+"/events", func(w http.ResponseWriter, r *http.Request) {
+		if name := r.PostFormValue("name"); name != "rpc/request" {
+			t.Errorf("Published event %v, expected rpc/request", name)
+		}
+
+		requests <- r.PostFormValue("data")
+		io.WriteString(w, `{"ok": true}`)
+	})
+
+	device := generateTestDevice("1", "photon", 10)
+	rpc := device.NewRPC("rpc/response")
+	rpc.Timeout = 50 * time.Millisecond
+
+	resp, err := rpc.Publish("rpc/request", "ping")
+
+	if err != nil {
+		t.Fatalf("Publish(): %v", err)
+	}
+
+	if resp != "pong" {
+		t.Errorf("Publish() = %v, expected pong", resp)
+	}
+
+	_, err = rpc.Publish("rpc/request", "unanswered")
+
+	if !errors.Is(err, ErrRPCTimeout) {
+		t.Errorf("Publish() returned %v, expected %v", err, ErrRPCTimeout)
+	}
+}
+
+func TestRPC_Call(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := make(chan string, 1)
+	handleRPCStream(t, requests)
+
+	mux.HandleFunc(deviceURL+"/1/ping", func(w http.ResponseWriter, r *http.Request) {
+		requests <- r.PostFormValue("arg")
+		io.WriteString(w, `{"id": "1", "connected": true, "return_value": 0}`)
+	})
+
+	device := generateTestDevice("1", "photon", 10)
+	device.Functions = []string{"ping"}
+	resp, err := device.NewRPC("rpc/response").Call("ping", "ping")
+
+	if err != nil {
+		t.Fatalf("Call(): %v", err)
+	}
+
+	if resp != "pong" {
+		t.Errorf("Call() = %v, expected pong", resp)
+	}
+}
@@ -0,0 +1,34 @@
+package particle
+
+import (
+	"net/url"
+)
+
+const serialNumberURL = "/v1/serial_numbers"
+
+// SerialNumberInfo is the identification of a device looked up by it's serial number.
+type SerialNumberInfo struct {
+	DeviceID   string   `json:"device_id"`
+	PlatformID Platform `json:"platform_id"`
+	ICCID      string
+}
+
+// LookupSerialNumber resolves the serial number printed on a devices label to the devices id and platform. The
+// ICCID or IMEI of cellular devices can be passed instead of the serial number as well.
+func (c *Client) LookupSerialNumber(serial string) (SerialNumberInfo, error) {
+	var info SerialNumberInfo
+	_, err := c.get(serialNumberURL+"/"+url.PathEscape(serial), &info)
+
+	return info, err
+}
+
+// GetDeviceBySerialNumber looks up the device with the given serial number, ICCID or IMEI and gets it.
+func (c *Client) GetDeviceBySerialNumber(serial string) (Device, error) {
+	info, err := c.LookupSerialNumber(serial)
+
+	if err != nil {
+		return Device{}, err
+	}
+
+	return c.GetDevice(info.DeviceID)
+}
@@ -0,0 +1,66 @@
+package particle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_LookupSerialNumber(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(serialNumberURL+"/E40KAB123456789", func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		fmt.Fprint(w, `{"ok": true, "device_id": "1", "platform_id": 10, "iccid": "8934076500002589174"}`)
+	})
+
+	info, err := client.LookupSerialNumber("E40KAB123456789")
+
+	if err != nil {
+		t.Fatalf("LookupSerialNumber(): %v", err)
+	}
+
+	expected := SerialNumberInfo{"1", PlatformElectron, "8934076500002589174"}
+	if info != expected {
+		t.Errorf("Serial number info = %v, expected %v", info, expected)
+	}
+}
+
+func TestClient_GetDeviceBySerialNumber(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "electron", 10)
+
+	mux.HandleFunc(serialNumberURL+"/E40KAB123456789", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok": true, "device_id": "1", "platform_id": 10}`)
+	})
+
+	mux.HandleFunc(deviceURL+"/"+device.ID, func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(w).Encode(device)
+
+		if err != nil {
+			t.Fatalf("Could not encode device: %v", err)
+		}
+	})
+
+	deviceResp, err := client.GetDeviceBySerialNumber("E40KAB123456789")
+
+	if err != nil {
+		t.Fatalf("GetDeviceBySerialNumber(): %v", err)
+	}
+
+	if !reflect.DeepEqual(deviceResp, device) {
+		t.Errorf("Response device %v doesn't match orignal: %v", deviceResp, device)
+	}
+
+	if _, err := client.GetDeviceBySerialNumber("unknown"); err == nil {
+		t.Errorf("GetDeviceBySerialNumber() for an unknown serial number returned no error")
+	}
+}
@@ -0,0 +1,189 @@
+package particle
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const simURL = "/v1/sims"
+
+// SIM information of a cellular device.
+type SIM struct {
+	ICCID               string `json:"_id"`
+	Status              string
+	Carrier             string
+	MSISDN              string
+	BaseCountry         string    `json:"base_country"`
+	ActivationsCount    int       `json:"activations_count"`
+	DeactivationsCount  int       `json:"deactivations_count"`
+	FirstActivatedOn    time.Time `json:"first_activated_on"`
+	LastActivatedOn     time.Time `json:"last_activated_on"`
+	LastStatusChange    string    `json:"last_status_change_action"`
+	LastStatusChangeErr string    `json:"last_status_change_action_error"`
+	MBLimit             float64   `json:"mb_limit"`
+	LastDeviceID        string    `json:"last_device_id"`
+	LastDeviceName      string    `json:"last_device_name"`
+	UpdatedAt           time.Time `json:"updated_at"`
+	OwnerID             string    `json:"user_id"`
+	client              *Client
+	baseURL             string
+}
+
+// SIMs is an array of the SIM type.
+type SIMs []SIM
+
+// simsResponse is the envelope the API wraps SIM lists in.
+type simsResponse struct {
+	Sims SIMs
+}
+
+// DataUsageDay is the cellular data usage of a single day of the current billing period.
+type DataUsageDay struct {
+	Date              string
+	MBsUsed           float64 `json:"mbs_used"`
+	MBsUsedCumulative float64 `json:"mbs_used_cumulative"`
+}
+
+// SIMDataUsage is the data usage of a single SIM for the current billing period.
+type SIMDataUsage struct {
+	ICCID      string
+	UsageByDay []DataUsageDay `json:"usage_by_day"`
+}
+
+// FleetDataUsage is the combined data usage of all SIMs of a product for the current billing period.
+type FleetDataUsage struct {
+	TotalMBsUsed        float64        `json:"total_mbs_used"`
+	TotalActiveSIMCards int            `json:"total_active_sim_cards"`
+	UsageByDay          []DataUsageDay `json:"usage_by_day"`
+}
+
+// listSIMs lists the SIMs at the given end point.
+func (c *Client) listSIMs(endPoint string) (SIMs, error) {
+	var resp simsResponse
+	_, err := c.get(endPoint, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range resp.Sims {
+		resp.Sims[idx].client = c
+		resp.Sims[idx].baseURL = endPoint
+	}
+
+	return resp.Sims, err
+}
+
+// getSIM gets the SIM with the given ICCID relative to the given end point.
+func (c *Client) getSIM(endPoint, iccid string) (SIM, error) {
+	var sim SIM
+	_, err := c.get(endPoint+"/"+iccid, &sim)
+
+	if err != nil {
+		return sim, err
+	}
+
+	sim.client = c
+	sim.baseURL = endPoint
+
+	return sim, err
+}
+
+// ListSIMs lists the SIMs owned by the user.
+func (c *Client) ListSIMs() (SIMs, error) {
+	return c.listSIMs(simURL)
+}
+
+// GetSIM gets a single SIM owned by the user by it's ICCID.
+func (c *Client) GetSIM(iccid string) (SIM, error) {
+	return c.getSIM(simURL, iccid)
+}
+
+// ListSIMs lists the SIMs of the product.
+func (p *Product) ListSIMs() (SIMs, error) {
+	return p.client.listSIMs(p.endPoint() + "/sims")
+}
+
+// GetSIM gets a single SIM of the product by it's ICCID.
+func (p *Product) GetSIM(iccid string) (SIM, error) {
+	return p.client.getSIM(p.endPoint()+"/sims", iccid)
+}
+
+// DataUsage returns the data usage of all SIMs in the product for the current billing period.
+func (p *Product) DataUsage() (FleetDataUsage, error) {
+	var usage FleetDataUsage
+	_, err := p.client.get(p.endPoint()+"/sims/data_usage", &usage)
+
+	return usage, err
+}
+
+// SIM gets the SIM which was last used by the device.
+func (d *Device) SIM() (SIM, error) {
+	if !d.Cellular || d.LastICCID == "" {
+		return SIM{}, fmt.Errorf("device %v has no SIM", d.ID)
+	}
+
+	return d.client.GetSIM(d.LastICCID)
+}
+
+// endPoint returns the API end point of the SIM.
+func (s *SIM) endPoint() string {
+	return s.baseURL + "/" + s.ICCID
+}
+
+// update sends the given form as update to the SIM and refreshes the SIM with the response.
+func (s *SIM) update(form url.Values) error {
+	_, err := s.client.put(s.endPoint(), form, s)
+
+	return err
+}
+
+// Activate activates the SIM.
+func (s *SIM) Activate() error {
+	form := url.Values{}
+	form.Add("action", "activate")
+
+	return s.update(form)
+}
+
+// Deactivate deactivates the SIM, so it won't be charged anymore.
+func (s *SIM) Deactivate() error {
+	form := url.Values{}
+	form.Add("action", "deactivate")
+
+	return s.update(form)
+}
+
+// Reactivate reactivates a SIM which was deactivated before.
+func (s *SIM) Reactivate() error {
+	form := url.Values{}
+	form.Add("action", "reactivate")
+
+	return s.update(form)
+}
+
+// SetDataLimit sets the monthly data limit of the SIM in MB. Once the limit is reached, the SIM gets paused.
+func (s *SIM) SetDataLimit(mb int) error {
+	form := url.Values{}
+	form.Add("mb_limit", strconv.Itoa(mb))
+
+	return s.update(form)
+}
+
+// Release releases the SIM from the users account or product.
+func (s *SIM) Release() error {
+	var resp okResponse
+	_, err := s.client.delete(s.endPoint(), &resp)
+
+	return err
+}
+
+// DataUsage returns the data usage of the SIM for the current billing period.
+func (s *SIM) DataUsage() (SIMDataUsage, error) {
+	var usage SIMDataUsage
+	_, err := s.client.get(s.endPoint()+"/data_usage", &usage)
+
+	return usage, err
+}
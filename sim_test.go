@@ -0,0 +1,211 @@
+package particle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// generateTestSIM generates a SIM owned by the user for testing.
+func generateTestSIM(iccid, status string) SIM {
+	sim := SIM{
+		ICCID:   iccid,
+		Status:  status,
+		Carrier: "telefonica",
+		client:  client,
+		baseURL: simURL,
+	}
+
+	return sim
+}
+
+func TestClient_ListSIMs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	sims := SIMs{generateTestSIM("8934076500002589174", "active"), generateTestSIM("8934076500002589175", "inactive")}
+
+	mux.HandleFunc(simURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		err := json.NewEncoder(w).Encode(simsResponse{sims})
+
+		if err != nil {
+			t.Fatalf("Could not encode SIMs: %v", err)
+		}
+	})
+
+	simsResp, err := client.ListSIMs()
+
+	if err != nil {
+		t.Fatalf("ListSIMs(): %v", err)
+	}
+
+	if !reflect.DeepEqual(simsResp, sims) {
+		t.Errorf("Response SIMs %v don't match with originals: %v", simsResp, sims)
+	}
+}
+
+func TestDevice_SIM(t *testing.T) {
+	setup()
+	defer teardown()
+
+	sim := generateTestSIM("8934076500002589174", "active")
+	device := generateTestDevice("1", "electron", 10)
+	device.LastICCID = sim.ICCID
+
+	mux.HandleFunc(simURL+"/"+sim.ICCID, func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		err := json.NewEncoder(w).Encode(sim)
+
+		if err != nil {
+			t.Fatalf("Could not encode SIM: %v", err)
+		}
+	})
+
+	simResp, err := device.SIM()
+
+	if err != nil {
+		t.Fatalf("SIM(): %v", err)
+	}
+
+	if !reflect.DeepEqual(simResp, sim) {
+		t.Errorf("Response SIM %v doesn't match orignal: %v", simResp, sim)
+	}
+
+	core := generateTestDevice("2", "core", 0)
+	if _, err := core.SIM(); err == nil {
+		t.Errorf("SIM() of a device without cellular returned no error")
+	}
+}
+
+func TestSIM_Actions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	product := generateTestProduct(1, "tracker")
+	sim := generateTestSIM("8934076500002589174", "inactive")
+	sim.baseURL = product.endPoint() + "/sims"
+
+	var action, limit string
+
+	mux.HandleFunc(sim.endPoint(), func(w http.ResponseWriter, r *http.Request) {
+		if m := "PUT"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		action = r.PostFormValue("action")
+		limit = r.PostFormValue("mb_limit")
+
+		fmt.Fprintf(w, `{"_id": "%v", "status": "%v"}`, sim.ICCID, action)
+	})
+
+	tests := []struct {
+		call   func() error
+		action string
+	}{
+		{sim.Activate, "activate"},
+		{sim.Deactivate, "deactivate"},
+		{sim.Reactivate, "reactivate"},
+	}
+
+	for _, test := range tests {
+		if err := test.call(); err != nil {
+			t.Fatalf("%v: %v", test.action, err)
+		}
+
+		if action != test.action {
+			t.Errorf("Sent action %v, expected %v", action, test.action)
+		}
+
+		if sim.Status != test.action {
+			t.Errorf("SIM status = %v, expected it to be updated to %v", sim.Status, test.action)
+		}
+	}
+
+	if err := sim.SetDataLimit(20); err != nil {
+		t.Fatalf("SetDataLimit(): %v", err)
+	}
+
+	if limit != "20" {
+		t.Errorf("Sent mb_limit %v, expected 20", limit)
+	}
+}
+
+func TestSIM_Release(t *testing.T) {
+	setup()
+	defer teardown()
+
+	sim := generateTestSIM("8934076500002589174", "active")
+	called := false
+
+	mux.HandleFunc(sim.endPoint(), func(w http.ResponseWriter, r *http.Request) {
+		if m := "DELETE"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		called = true
+		fmt.Fprint(w, `{"ok": true}`)
+	})
+
+	if err := sim.Release(); err != nil {
+		t.Fatalf("Release(): %v", err)
+	}
+
+	if !called {
+		t.Errorf("Release() didn't call the API")
+	}
+}
+
+func TestSIM_DataUsage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	sim := generateTestSIM("8934076500002589174", "active")
+
+	mux.HandleFunc(sim.endPoint()+"/data_usage", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"iccid": "%v", "usage_by_day": [{"date": "2016-08-01", "mbs_used": 0.5, "mbs_used_cumulative": 0.5},
+			{"date": "2016-08-02", "mbs_used": 1.25, "mbs_used_cumulative": 1.75}]}`, sim.ICCID)
+	})
+
+	usage, err := sim.DataUsage()
+
+	if err != nil {
+		t.Fatalf("DataUsage(): %v", err)
+	}
+
+	expected := SIMDataUsage{sim.ICCID, []DataUsageDay{{"2016-08-01", 0.5, 0.5}, {"2016-08-02", 1.25, 1.75}}}
+	if !reflect.DeepEqual(usage, expected) {
+		t.Errorf("Data usage = %v, expected %v", usage, expected)
+	}
+}
+
+func TestProduct_DataUsage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	product := generateTestProduct(1, "tracker")
+
+	mux.HandleFunc(product.endPoint()+"/sims/data_usage", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total_mbs_used": 42.5, "total_active_sim_cards": 3,
+			"usage_by_day": [{"date": "2016-08-01", "mbs_used": 42.5, "mbs_used_cumulative": 42.5}]}`)
+	})
+
+	usage, err := product.DataUsage()
+
+	if err != nil {
+		t.Fatalf("DataUsage(): %v", err)
+	}
+
+	expected := FleetDataUsage{42.5, 3, []DataUsageDay{{"2016-08-01", 42.5, 42.5}}}
+	if !reflect.DeepEqual(usage, expected) {
+		t.Errorf("Data usage = %v, expected %v", usage, expected)
+	}
+}
@@ -0,0 +1,50 @@
+package particle
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	oauthTokenURL   = "/oauth/token"
+	currentTokenURL = "/v1/access_tokens/current"
+)
+
+// AccessToken represents an OAuth token issued by the particle cloud.
+type AccessToken struct {
+	TokenType    string `json:"token_type"`
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string
+}
+
+// TokenInfo describes the token a client uses.
+type TokenInfo struct {
+	ClientID string `json:"client_id"`
+	Scopes   []string
+	// ExpiresAt is nil for tokens which never expire.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Expired reports whether the token was expired at the given time.
+func (info TokenInfo) Expired(now time.Time) bool {
+	return info.ExpiresAt != nil && !now.Before(*info.ExpiresAt)
+}
+
+// ValidateToken checks that the clients token is known by the cloud and not expired yet, and reports it's scopes and
+// expiry.
+func (c *Client) ValidateToken() (TokenInfo, error) {
+	var info TokenInfo
+	_, err := c.get(currentTokenURL, &info)
+
+	if err != nil {
+		return info, err
+	}
+
+	if info.Expired(time.Now()) {
+		return info, fmt.Errorf("token expired at %v", info.ExpiresAt)
+	}
+
+	return info, nil
+}
@@ -0,0 +1,57 @@
+package particle
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClient_ValidateToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	mux.HandleFunc(currentTokenURL, func(w http.ResponseWriter, r *http.Request) {
+		if a := r.Header.Get("Authorization"); a != "Bearer "+client.Token {
+			t.Errorf("Authorization = %v, expected the clients token", a)
+		}
+
+		fmt.Fprintf(w, `{"client_id": "cli", "scopes": ["devices:list"], "expires_at": "%v"}`,
+			expiresAt.Format(time.RFC3339))
+	})
+
+	info, err := client.ValidateToken()
+
+	if err != nil {
+		t.Fatalf("ValidateToken(): %v", err)
+	}
+
+	if info.ClientID != "cli" || !reflect.DeepEqual(info.Scopes, []string{"devices:list"}) {
+		t.Errorf("Token info = %v, expected client cli with scope devices:list", info)
+	}
+
+	if info.ExpiresAt == nil || !info.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("Token expires at %v, expected %v", info.ExpiresAt, expiresAt)
+	}
+}
+
+func TestClient_ValidateTokenExpired(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(currentTokenURL, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"client_id": "cli", "expires_at": "2016-08-01T12:30:00Z"}`)
+	})
+
+	if _, err := client.ValidateToken(); err == nil {
+		t.Errorf("ValidateToken() accepted an expired token")
+	}
+
+	info := TokenInfo{}
+	if info.Expired(time.Now()) {
+		t.Errorf("Token without expiry reported as expired")
+	}
+}
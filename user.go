@@ -0,0 +1,32 @@
+package particle
+
+const userURL = "/v1/user"
+
+// AccountLimits are the limits of the users account.
+type AccountLimits struct {
+	Devices     int
+	Products    int
+	TeamMembers int `json:"team_members"`
+}
+
+// User information of the account a token belongs to.
+type User struct {
+	ID            string
+	Username      string
+	Organizations Organizations `json:"-"`
+	Limits        AccountLimits
+}
+
+// CurrentUser returns the user the clients token belongs to, including the organizations the user is a member of.
+func (c *Client) CurrentUser() (User, error) {
+	var user User
+	_, err := c.get(userURL, &user)
+
+	if err != nil {
+		return user, err
+	}
+
+	user.Organizations, err = c.ListOrganizations()
+
+	return user, err
+}
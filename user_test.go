@@ -0,0 +1,42 @@
+package particle
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_CurrentUser(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(userURL, func(w http.ResponseWriter, r *http.Request) {
+		if m := "GET"; m != r.Method {
+			t.Errorf("Request method = %v, expected %v", r.Method, m)
+		}
+
+		fmt.Fprint(w, `{"id": "u1", "username": "jane@example.com", "limits": {"devices": 100, "products": 5, "team_members": 3}}`)
+	})
+
+	mux.HandleFunc(organizationURL, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"organizations": [{"id": "o1", "slug": "acme", "name": "ACME"}]}`)
+	})
+
+	user, err := client.CurrentUser()
+
+	if err != nil {
+		t.Fatalf("CurrentUser(): %v", err)
+	}
+
+	expected := User{
+		ID:            "u1",
+		Username:      "jane@example.com",
+		Organizations: Organizations{{"o1", "acme", "ACME", client}},
+		Limits:        AccountLimits{100, 5, 3},
+	}
+
+	if !reflect.DeepEqual(user, expected) {
+		t.Errorf("User = %v, expected %v", user, expected)
+	}
+}
@@ -0,0 +1,221 @@
+package particle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// VariableType is the type a device declared a variable with.
+type VariableType string
+
+// The variable types a device can declare.
+const (
+	VariableTypeInt32  VariableType = "int32"
+	VariableTypeDouble VariableType = "double"
+	VariableTypeBool   VariableType = "bool"
+	VariableTypeString VariableType = "string"
+)
+
+// ErrUndeclaredVariable is returned when a variable is read, which the device didn't declare.
+var ErrUndeclaredVariable = errors.New("variable is not declared by the device")
+
+// ErrVariableType is returned when a variables value doesn't match it's type.
+var ErrVariableType = errors.New("variable value doesn't match it's type")
+
+// VariableInfo is the metadata of a device variable.
+type VariableInfo struct {
+	Name string
+	Type VariableType
+}
+
+// CoreInfo is the connection information of the device a variable was read from.
+type CoreInfo struct {
+	DeviceID        string `json:"deviceID"`
+	LastApp         string `json:"last_app"`
+	Connected       bool
+	ProductID       int        `json:"product_id"`
+	LastHeard       *time.Time `json:"last_heard"`
+	LastHandshakeAt *time.Time `json:"last_handshake_at"`
+}
+
+// UnmarshalJSON decodes the core info, parsing it's timestamps with parseTimestamp.
+func (info *CoreInfo) UnmarshalJSON(data []byte) error {
+	type coreInfo CoreInfo
+
+	aux := struct {
+		*coreInfo
+		LastHeard       json.RawMessage `json:"last_heard"`
+		LastHandshakeAt json.RawMessage `json:"last_handshake_at"`
+	}{coreInfo: (*coreInfo)(info)}
+
+	err := json.Unmarshal(data, &aux)
+
+	if err != nil {
+		return err
+	}
+
+	info.LastHeard, err = parseTimestamp(aux.LastHeard)
+
+	if err != nil {
+		return err
+	}
+
+	info.LastHandshakeAt, err = parseTimestamp(aux.LastHandshakeAt)
+
+	return err
+}
+
+// VariableResponse is the full response of the API when reading a variable.
+type VariableResponse struct {
+	Cmd      string
+	Name     string
+	Result   json.RawMessage
+	CoreInfo CoreInfo `json:"coreInfo"`
+	// ReadAt is the time the response was received.
+	ReadAt time.Time `json:"-"`
+}
+
+// Decode decodes the JSON result of the variable into v.
+func (r VariableResponse) Decode(v interface{}) error {
+	return json.Unmarshal(r.Result, v)
+}
+
+// Age returns the time between the device being last heard and the variable being read, which tells how stale the
+// value might be. It is 0 if the device was never heard.
+func (r VariableResponse) Age() time.Duration {
+	if r.CoreInfo.LastHeard == nil {
+		return 0
+	}
+
+	return r.ReadAt.Sub(*r.CoreInfo.LastHeard)
+}
+
+// Value is the value of a device variable, converted to the Go type matching the variables declared type.
+type Value struct {
+	VariableInfo
+	// Value is an int, float64, bool or string, depending on the declared type.
+	Value interface{}
+}
+
+// Int returns the value of an int32 variable.
+func (v Value) Int() (int, error) {
+	i, ok := v.Value.(int)
+
+	if !ok {
+		return 0, fmt.Errorf("%v is %v not int32: %w", v.Name, v.Type, ErrVariableType)
+	}
+
+	return i, nil
+}
+
+// Float returns the value of a double variable.
+func (v Value) Float() (float64, error) {
+	f, ok := v.Value.(float64)
+
+	if !ok {
+		return 0, fmt.Errorf("%v is %v not double: %w", v.Name, v.Type, ErrVariableType)
+	}
+
+	return f, nil
+}
+
+// Bool returns the value of a bool variable.
+func (v Value) Bool() (bool, error) {
+	b, ok := v.Value.(bool)
+
+	if !ok {
+		return false, fmt.Errorf("%v is %v not bool: %w", v.Name, v.Type, ErrVariableType)
+	}
+
+	return b, nil
+}
+
+// String returns the value formatted as string, regardless of it's type.
+func (v Value) String() string {
+	return fmt.Sprint(v.Value)
+}
+
+// VariableInfo returns the metadata of the variable with the given name. If the device was listed without it's
+// variables, then it's refreshed first.
+func (d *Device) VariableInfo(name string) (VariableInfo, error) {
+	variables := d.variables()
+
+	if variables == nil {
+		if err := d.Refresh(); err != nil {
+			return VariableInfo{}, err
+		}
+
+		variables = d.variables()
+	}
+
+	typ, ok := variables[name]
+
+	if !ok {
+		return VariableInfo{}, fmt.Errorf("%v on device %v: %w", name, d.ID, ErrUndeclaredVariable)
+	}
+
+	return VariableInfo{name, VariableType(typ)}, nil
+}
+
+// variables returns the variables the device declared, it is safe to use while the device is kept updated.
+func (d *Device) variables() map[string]string {
+	d.lock()
+	defer d.unlock()
+
+	return d.Variables
+}
+
+// Variable reads the variable with the given name and converts it to the Go type matching it's declared type.
+func (d *Device) Variable(name string) (Value, error) {
+	info, err := d.VariableInfo(name)
+
+	if err != nil {
+		return Value{}, err
+	}
+
+	raw, err := d.VariableString(name)
+
+	if err != nil {
+		return Value{VariableInfo: info}, err
+	}
+
+	return parseValue(info, raw)
+}
+
+// VariableResponse reads the variable with the given name and returns the APIs full response, including the
+// connection information of the device.
+func (d *Device) VariableResponse(name string) (VariableResponse, error) {
+	var resp VariableResponse
+	_, err := d.client.get(deviceURL+"/"+d.ID+"/"+name, &resp)
+	resp.ReadAt = time.Now()
+
+	return resp, err
+}
+
+// parseValue converts the raw value of a variable to the Go type matching the variables type.
+func parseValue(info VariableInfo, raw string) (Value, error) {
+	v := Value{VariableInfo: info}
+	var err error
+
+	switch info.Type {
+	case VariableTypeInt32:
+		v.Value, err = strconv.Atoi(raw)
+	case VariableTypeDouble:
+		v.Value, err = strconv.ParseFloat(raw, 64)
+	case VariableTypeBool:
+		v.Value, err = strconv.ParseBool(raw)
+	case VariableTypeString:
+		v.Value = raw
+	default:
+		return v, fmt.Errorf("%v has unknown type %v: %w", info.Name, info.Type, ErrVariableType)
+	}
+
+	if err != nil {
+		return Value{VariableInfo: info}, fmt.Errorf("%v = %q: %w", info.Name, raw, ErrVariableType)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,144 @@
+package particle
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// variableTag is the struct tag ReadVariables reads the variable names from.
+const variableTag = "particle"
+
+// VariableFieldError is the error of reading a single variable into a struct field.
+type VariableFieldError struct {
+	Field    string
+	Variable string
+	Err      error
+}
+
+func (e *VariableFieldError) Error() string {
+	return fmt.Sprintf("%v (%v): %v", e.Field, e.Variable, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *VariableFieldError) Unwrap() error {
+	return e.Err
+}
+
+// VariablesError lists every field ReadVariables failed to populate, in the order of the struct fields.
+type VariablesError []*VariableFieldError
+
+func (e VariablesError) Error() string {
+	msgs := make([]string, len(e))
+
+	for idx, err := range e {
+		msgs[idx] = err.Error()
+	}
+
+	return "reading variables failed: " + strings.Join(msgs, "; ")
+}
+
+// ReadVariables populates the exported fields of the struct v points to with the values of the device variables
+// named by their `particle:"name"` tags. Fields without tag are skipped. The variables are read concurrently and
+// converted to the type of their field, which may be a string, bool, integer or float. If any field fails, then a
+// VariablesError listing all failed fields is returned, the other fields are populated regardless.
+func (d *Device) ReadVariables(v interface{}) error {
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ReadVariables needs a pointer to a struct, got %T", v)
+	}
+
+	rv = rv.Elem()
+	rt := rv.Type()
+	errs := make([]*VariableFieldError, rt.NumField())
+	var wg sync.WaitGroup
+
+	for idx := 0; idx < rt.NumField(); idx++ {
+		field := rt.Field(idx)
+		name := field.Tag.Get(variableTag)
+
+		if name == "" || name == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(idx int, field reflect.StructField, name string) {
+			defer wg.Done()
+
+			raw, err := d.VariableString(name)
+
+			if err == nil {
+				err = setVariableField(rv.Field(idx), raw)
+			}
+
+			if err != nil {
+				errs[idx] = &VariableFieldError{field.Name, name, err}
+			}
+		}(idx, field, name)
+	}
+
+	wg.Wait()
+
+	var failed VariablesError
+
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+
+	if len(failed) > 0 {
+		return failed
+	}
+
+	return nil
+}
+
+// setVariableField converts the raw variable value to the type of the field and sets it.
+func setVariableField(field reflect.Value, raw string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 10, field.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, field.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(f)
+	default:
+		return errors.New("unsupported field type " + field.Type().String())
+	}
+
+	return nil
+}
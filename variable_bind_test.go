@@ -0,0 +1,71 @@
+package particle
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestDevice_ReadVariables(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "photon", 6)
+	values := map[string]string{"temperature": "21.5", "state": "idle", "count": "42", "on": "true", "level": "300"}
+
+	for name, value := range values {
+		value := value
+
+		mux.HandleFunc(deviceURL+"/"+device.ID+"/"+name, func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, value)
+		})
+	}
+
+	var config struct {
+		Temperature float64 `particle:"temperature"`
+		State       string  `particle:"state"`
+		Count       int     `particle:"count"`
+		On          bool    `particle:"on"`
+		Ignored     string
+		Skipped     string `particle:"-"`
+	}
+
+	if err := device.ReadVariables(&config); err != nil {
+		t.Fatalf("ReadVariables(): %v", err)
+	}
+
+	if config.Temperature != 21.5 || config.State != "idle" || config.Count != 42 || !config.On {
+		t.Errorf("Config = %+v, doesn't match the variables %v", config, values)
+	}
+
+	var broken struct {
+		Level   int8   `particle:"level"`
+		Missing string `particle:"missing"`
+		State   string `particle:"state"`
+	}
+
+	err := device.ReadVariables(&broken)
+
+	var failed VariablesError
+	if !errors.As(err, &failed) {
+		t.Fatalf("ReadVariables() returned %v, expected a VariablesError", err)
+	}
+
+	if len(failed) != 2 || failed[0].Field != "Level" || failed[1].Variable != "missing" {
+		t.Errorf("Failed fields = %v, expected Level and Missing", failed)
+	}
+
+	if !errors.Is(failed[0], strconv.ErrRange) {
+		t.Errorf("Level failed with %v, expected %v", failed[0].Err, strconv.ErrRange)
+	}
+
+	if broken.State != "idle" {
+		t.Errorf("State = %v, expected the successful fields to be populated", broken.State)
+	}
+
+	if err := device.ReadVariables(broken); err == nil {
+		t.Errorf("ReadVariables() accepted a struct value")
+	}
+}
@@ -0,0 +1,135 @@
+package particle
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDevice_Variable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "photon", 6)
+	device.Variables = map[string]string{"anInt": "int32", "aDouble": "double", "aString": "string", "aBool": "bool"}
+	values := map[string]string{"anInt": "666", "aDouble": "3.14", "aString": "My name is particle", "aBool": "true"}
+
+	for name, value := range values {
+		value := value
+
+		mux.HandleFunc(deviceURL+"/"+device.ID+"/"+name, func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, value)
+		})
+	}
+
+	expected := map[string]interface{}{"anInt": 666, "aDouble": 3.14, "aString": "My name is particle", "aBool": true}
+
+	for name, e := range expected {
+		v, err := device.Variable(name)
+
+		if err != nil {
+			t.Fatalf("Variable(%v): %v", name, err)
+		}
+
+		if v.Value != e || v.Name != name || string(v.Type) != device.Variables[name] {
+			t.Errorf("Variable(%v) = %v, expected %v of type %v", name, v, e, device.Variables[name])
+		}
+	}
+
+	v, _ := device.Variable("anInt")
+
+	if i, err := v.Int(); err != nil || i != 666 {
+		t.Errorf("Int() = %v, %v, expected 666", i, err)
+	}
+
+	if _, err := v.Float(); !errors.Is(err, ErrVariableType) {
+		t.Errorf("Float() of an int32 variable returned %v, expected %v", err, ErrVariableType)
+	}
+
+	if _, err := device.Variable("unknown"); !errors.Is(err, ErrUndeclaredVariable) {
+		t.Errorf("Variable(unknown) returned %v, expected %v", err, ErrUndeclaredVariable)
+	}
+}
+
+func TestDevice_VariableTypeMismatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "photon", 6)
+
+	mux.HandleFunc(deviceURL+"/"+device.ID, func(w http.ResponseWriter, r *http.Request) {
+		d := device
+		d.Variables = map[string]string{"anInt": "int32"}
+
+		err := json.NewEncoder(w).Encode(d)
+
+		if err != nil {
+			t.Fatalf("Could not encode device: %v", err)
+		}
+	})
+
+	mux.HandleFunc(deviceURL+"/"+device.ID+"/anInt", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "not a number")
+	})
+
+	_, err := device.Variable("anInt")
+
+	if !errors.Is(err, ErrVariableType) {
+		t.Errorf("Variable() returned %v, expected %v", err, ErrVariableType)
+	}
+
+	if device.Variables["anInt"] != "int32" {
+		t.Errorf("Device without variables wasn't refreshed, variables = %v", device.Variables)
+	}
+}
+
+func TestDevice_VariableResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "photon", 6)
+
+	mux.HandleFunc(deviceURL+"/"+device.ID+"/temperature", func(w http.ResponseWriter, r *http.Request) {
+		if f := r.URL.Query().Get("format"); f != "" {
+			t.Errorf("Query format = %v, expected the JSON response", f)
+		}
+
+		io.WriteString(w, `{"cmd": "VarReturn", "name": "temperature", "result": 21.5, "coreInfo": {
+			"last_app": "", "last_heard": "2016-08-01T12:30:00.000Z", "connected": true,
+			"last_handshake_at": "", "deviceID": "1", "product_id": 6}}`)
+	})
+
+	resp, err := device.VariableResponse("temperature")
+
+	if err != nil {
+		t.Fatalf("VariableResponse(): %v", err)
+	}
+
+	if resp.Name != "temperature" || resp.Cmd != "VarReturn" {
+		t.Errorf("Response = %v, expected the temperature variable", resp)
+	}
+
+	var temperature float64
+	if err := resp.Decode(&temperature); err != nil || temperature != 21.5 {
+		t.Errorf("Decode() = %v, %v, expected 21.5", temperature, err)
+	}
+
+	info := resp.CoreInfo
+
+	if !info.Connected || info.DeviceID != "1" || info.ProductID != 6 || info.LastHandshakeAt != nil {
+		t.Errorf("Core info = %v, expected connected device 1 of product 6", info)
+	}
+
+	lastHeard := time.Date(2016, 8, 1, 12, 30, 0, 0, time.UTC)
+
+	if info.LastHeard == nil || !info.LastHeard.Equal(lastHeard) {
+		t.Errorf("Last heard = %v, expected %v", info.LastHeard, lastHeard)
+	}
+
+	if age := resp.Age(); age != resp.ReadAt.Sub(lastHeard) {
+		t.Errorf("Age = %v, expected %v", age, resp.ReadAt.Sub(lastHeard))
+	}
+}
@@ -0,0 +1,178 @@
+package particle
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// VariableUpdate is emitted by a VariableWatcher when the watched variable changed or couldn't be read.
+type VariableUpdate struct {
+	Name string
+	// Value is the raw value of the variable. It is empty if Err is set.
+	Value string
+	// Previous is the value of the last update, empty for the first one.
+	Previous string
+	// Err is set when the variable couldn't be read, e.g. because the device went offline.
+	Err  error
+	Time time.Time
+}
+
+// defaultWatchInterval is the interval a VariableWatcher polls at, if no positive interval was set.
+const defaultWatchInterval = 10 * time.Second
+
+// VariableWatcher polls a device variable and emits an update whenever it's value changed. For numeric variables
+// the updates can be limited to changes larger than a deadband or to crossings of thresholds. The first value read is
+// always emitted, as is the first value read after the variable couldn't be read.
+type VariableWatcher struct {
+	Device *Device
+	Name   string
+	// Interval between two reads, defaults to 10 seconds.
+	Interval time.Duration
+	// Jitter adds a random delay of up to Jitter to every interval, so many watchers don't poll in lockstep.
+	Jitter time.Duration
+	// Deadband suppresses numeric changes which are not larger than it, compared to the last emitted value.
+	Deadband float64
+	// Thresholds, if set, only emit numeric changes which cross one of the thresholds.
+	Thresholds []float64
+	// MaxBackoff limits how long the interval grows while the variable can't be read. The interval doubles after
+	// every failed read, up to MaxBackoff, which defaults to 16 times the Interval.
+	MaxBackoff time.Duration
+}
+
+// NewVariableWatcher creates a watcher for the variable with the given name, which polls at the given interval. A
+// non-positive interval polls at the default interval of 10 seconds.
+func (d *Device) NewVariableWatcher(name string, interval time.Duration) *VariableWatcher {
+	return &VariableWatcher{
+		Device:   d,
+		Name:     name,
+		Interval: interval,
+	}
+}
+
+// Watch starts polling in the background and returns the channel the updates are sent to. The channel is closed once
+// the context is done.
+func (w *VariableWatcher) Watch(ctx context.Context) <-chan VariableUpdate {
+	updates := make(chan VariableUpdate)
+
+	go func() {
+		defer close(updates)
+
+		w.Run(ctx, func(u VariableUpdate) {
+			select {
+			case updates <- u:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return updates
+}
+
+// Run polls the variable and calls fn for every update until the context is done. It returns the contexts error.
+func (w *VariableWatcher) Run(ctx context.Context, fn func(VariableUpdate)) error {
+	var last *VariableUpdate
+	failing := false
+	backoff := w.interval()
+
+	for {
+		value, err := w.Device.VariableString(w.Name)
+		now := time.Now()
+
+		if err != nil {
+			// Only report the first error of an outage and poll less often until the device is back.
+			if !failing {
+				fn(VariableUpdate{Name: w.Name, Err: err, Time: now})
+				failing = true
+			}
+
+			backoff = w.nextBackoff(backoff)
+		} else {
+			recovered := failing
+			failing = false
+			backoff = w.interval()
+
+			if last == nil || recovered || w.changed(last.Value, value) {
+				u := VariableUpdate{Name: w.Name, Value: value, Time: now}
+
+				if last != nil {
+					u.Previous = last.Value
+				}
+
+				last = &u
+				fn(u)
+			}
+		}
+
+		timer := time.NewTimer(w.delay(backoff))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// interval returns the interval to poll at.
+func (w *VariableWatcher) interval() time.Duration {
+	if w.Interval > 0 {
+		return w.Interval
+	}
+
+	return defaultWatchInterval
+}
+
+// nextBackoff doubles the given interval, up to the maximum backoff.
+func (w *VariableWatcher) nextBackoff(current time.Duration) time.Duration {
+	max := w.MaxBackoff
+
+	if max <= 0 {
+		max = 16 * w.interval()
+	}
+
+	if current *= 2; current > max {
+		return max
+	}
+
+	return current
+}
+
+// delay adds the jitter to the given interval.
+func (w *VariableWatcher) delay(interval time.Duration) time.Duration {
+	if w.Jitter <= 0 {
+		return interval
+	}
+
+	return interval + time.Duration(rand.Int63n(int64(w.Jitter)))
+}
+
+// changed reports whether the change from the previous to the current value should be emitted.
+func (w *VariableWatcher) changed(previous, current string) bool {
+	if previous == current {
+		return false
+	}
+
+	prev, errPrev := strconv.ParseFloat(previous, 64)
+	cur, errCur := strconv.ParseFloat(current, 64)
+
+	// Non-numeric values and watchers without numeric filters emit any change.
+	if errPrev != nil || errCur != nil || (w.Deadband <= 0 && len(w.Thresholds) == 0) {
+		return true
+	}
+
+	if w.Deadband > 0 && math.Abs(cur-prev) > w.Deadband {
+		return true
+	}
+
+	for _, threshold := range w.Thresholds {
+		if (prev < threshold) != (cur < threshold) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,132 @@
+package particle
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestVariableWatcher_Watch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	device := generateTestDevice("1", "photon", 6)
+	values := []string{"20", "20", "20.2", "21", "25", "", "", "25.5"}
+	var mu sync.Mutex
+	reads := 0
+
+	mux.HandleFunc(deviceURL+"/"+device.ID+"/temperature", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		value := values[len(values)-1]
+		if reads < len(values) {
+			value = values[reads]
+		}
+		reads++
+		mu.Unlock()
+
+		if value == "" {
+			http.Error(w, `{"error": "Timed out."}`, http.StatusRequestTimeout)
+			return
+		}
+
+		io.WriteString(w, value)
+	})
+
+	w := device.NewVariableWatcher("temperature", time.Millisecond)
+	w.Deadband = 0.5
+	w.Thresholds = []float64{24}
+	w.MaxBackoff = 4 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var updates []VariableUpdate
+
+	for u := range w.Watch(ctx) {
+		updates = append(updates, u)
+
+		mu.Lock()
+		done := reads > len(values)+2
+		mu.Unlock()
+
+		if len(updates) == 5 {
+			// Give the watcher time to read the unchanged values after the recovery, which mustn't be emitted.
+			time.Sleep(20 * time.Millisecond)
+			cancel()
+		} else if done {
+			break
+		}
+	}
+
+	expected := []string{"20", "21", "25", "", "25.5"}
+
+	if len(updates) != len(expected) {
+		t.Fatalf("Got %v updates, expected %v: %v", len(updates), len(expected), updates)
+	}
+
+	for idx, u := range updates {
+		if u.Value != expected[idx] {
+			t.Errorf("Update %v = %v, expected %v", idx, u.Value, expected[idx])
+		}
+	}
+
+	if updates[2].Previous != "21" {
+		t.Errorf("Previous value of update 2 = %v, expected 21", updates[2].Previous)
+	}
+
+	if updates[3].Err == nil {
+		t.Errorf("Update 3 = %v, expected the read error", updates[3])
+	}
+
+	// The recovery is emitted, although it's within the deadband.
+	if updates[4].Err != nil || updates[4].Previous != "25" {
+		t.Errorf("Update 4 = %v, expected the recovery from 25", updates[4])
+	}
+}
+
+func TestVariableWatcher_Changed(t *testing.T) {
+	tests := []struct {
+		watcher  VariableWatcher
+		previous string
+		current  string
+		changed  bool
+	}{
+		{VariableWatcher{}, "idle", "busy", true},
+		{VariableWatcher{}, "1", "1", false},
+		{VariableWatcher{}, "1", "1.1", true},
+		{VariableWatcher{Deadband: 1}, "1", "1.5", false},
+		{VariableWatcher{Deadband: 1}, "1", "2.5", true},
+		{VariableWatcher{Thresholds: []float64{10, 20}}, "5", "9", false},
+		{VariableWatcher{Thresholds: []float64{10, 20}}, "9", "11", true},
+		{VariableWatcher{Thresholds: []float64{10, 20}}, "25", "15", true},
+		{VariableWatcher{Deadband: 5, Thresholds: []float64{10}}, "9", "10", true},
+	}
+
+	for _, test := range tests {
+		if c := test.watcher.changed(test.previous, test.current); c != test.changed {
+			t.Errorf("changed(%v, %v) with deadband %v and thresholds %v = %v, expected %v", test.previous,
+				test.current, test.watcher.Deadband, test.watcher.Thresholds, c, test.changed)
+		}
+	}
+}
+
+func TestVariableWatcher_Backoff(t *testing.T) {
+	w := VariableWatcher{}
+
+	if i := w.interval(); i != defaultWatchInterval {
+		t.Errorf("interval() = %v, expected %v", i, defaultWatchInterval)
+	}
+
+	if b := w.nextBackoff(w.interval()); b != 2*defaultWatchInterval {
+		t.Errorf("nextBackoff() = %v, expected %v", b, 2*defaultWatchInterval)
+	}
+
+	w = VariableWatcher{Interval: time.Second, MaxBackoff: 3 * time.Second}
+
+	if b := w.nextBackoff(2 * time.Second); b != 3*time.Second {
+		t.Errorf("nextBackoff() = %v, expected it to be capped at %v", b, w.MaxBackoff)
+	}
+}
@@ -0,0 +1,115 @@
+package particle
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// DefaultWebhookSecretHeader is the header WebhookHandler expects the shared secret in, if no other header was set.
+const DefaultWebhookSecretHeader = "X-Particle-Secret"
+
+// webhookPayload is the data particle webhooks send with their default form or JSON template.
+type webhookPayload struct {
+	Event       string `json:"event"`
+	Data        string `json:"data"`
+	CoreID      string `json:"coreid"`
+	PublishedAt string `json:"published_at"`
+}
+
+// WebhookHandler is an http.Handler which receives the requests of particle webhook integrations and passes them as
+// Event to an EventHandler. If Secret is set, then only requests which carry the secret in the SecretHeader are
+// accepted.
+type WebhookHandler struct {
+	Handler      EventHandler
+	Secret       string
+	SecretHeader string
+}
+
+// NewWebhookHandler creates a new WebhookHandler, which passes received events to the given handler. The secret is
+// optional and is expected in the DefaultWebhookSecretHeader.
+func NewWebhookHandler(h EventHandler, secret string) *WebhookHandler {
+	return &WebhookHandler{
+		Handler:      h,
+		Secret:       secret,
+		SecretHeader: DefaultWebhookSecretHeader,
+	}
+}
+
+// ServeHTTP decodes the webhook request and dispatches the event.
+func (wh *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" && r.Method != "PUT" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !wh.authorized(r) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	e, err := ParseWebhookRequest(r)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	wh.Handler.HandleEvent(e)
+}
+
+// authorized checks the shared secret of the request, if the handler has one.
+func (wh *WebhookHandler) authorized(r *http.Request) bool {
+	if wh.Secret == "" {
+		return true
+	}
+
+	header := wh.SecretHeader
+
+	if header == "" {
+		header = DefaultWebhookSecretHeader
+	}
+
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get(header)), []byte(wh.Secret)) == 1
+}
+
+// ParseWebhookRequest decodes the event of a webhook request. Both the default form and JSON templates are supported.
+func ParseWebhookRequest(r *http.Request) (Event, error) {
+	var payload webhookPayload
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	if mediaType == mediaTypeJSON {
+		err := json.NewDecoder(r.Body).Decode(&payload)
+
+		if err != nil {
+			return Event{}, err
+		}
+	} else {
+		err := r.ParseForm()
+
+		if err != nil {
+			return Event{}, err
+		}
+
+		payload.Event = r.Form.Get("event")
+		payload.Data = r.Form.Get("data")
+		payload.CoreID = r.Form.Get("coreid")
+		payload.PublishedAt = r.Form.Get("published_at")
+	}
+
+	e := Event{Name: payload.Event, Data: payload.Data, CoreID: payload.CoreID}
+
+	if payload.PublishedAt != "" {
+		publishedAt, err := time.Parse(time.RFC3339, payload.PublishedAt)
+
+		if err != nil {
+			return e, err
+		}
+
+		e.PublishedAt = publishedAt
+	}
+
+	return e, nil
+}
@@ -0,0 +1,78 @@
+package particle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookHandler_ServeHTTP(t *testing.T) {
+	publishedAt := time.Date(2016, 8, 1, 12, 30, 0, 0, time.UTC)
+	expected := Event{Name: "temperature", Data: "21.5", CoreID: "1", PublishedAt: publishedAt}
+
+	form := url.Values{}
+	form.Add("event", expected.Name)
+	form.Add("data", expected.Data)
+	form.Add("coreid", expected.CoreID)
+	form.Add("published_at", publishedAt.Format(time.RFC3339))
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		secret      string
+		status      int
+	}{
+		{"form", mediaTypeForm, form.Encode(), "s3cr3t", http.StatusOK},
+		{"json", mediaTypeJSON + "; charset=utf-8",
+			`{"event": "temperature", "data": "21.5", "coreid": "1", "published_at": "2016-08-01T12:30:00Z"}`,
+			"s3cr3t", http.StatusOK},
+		{"wrong secret", mediaTypeForm, form.Encode(), "guess", http.StatusUnauthorized},
+		{"invalid json", mediaTypeJSON, `{"event": `, "s3cr3t", http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		var received []Event
+		handler := NewWebhookHandler(EventHandlerFunc(func(e Event) {
+			received = append(received, e)
+		}), "s3cr3t")
+
+		req := httptest.NewRequest("POST", "/hook", strings.NewReader(test.body))
+		req.Header.Set("Content-Type", test.contentType)
+		req.Header.Set(DefaultWebhookSecretHeader, test.secret)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != test.status {
+			t.Errorf("%v: status = %v, expected %v", test.name, w.Code, test.status)
+		}
+
+		if test.status != http.StatusOK {
+			if len(received) != 0 {
+				t.Errorf("%v: handler received %v, although the request was rejected", test.name, received)
+			}
+			continue
+		}
+
+		if len(received) != 1 || received[0] != expected {
+			t.Errorf("%v: handler received %v, expected %v", test.name, received, expected)
+		}
+	}
+}
+
+func TestWebhookHandler_Method(t *testing.T) {
+	handler := NewWebhookHandler(EventHandlerFunc(func(e Event) {
+		t.Errorf("Handler received %v for a GET request", e)
+	}), "")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/hook", nil))
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Status = %v, expected %v", w.Code, http.StatusMethodNotAllowed)
+	}
+}